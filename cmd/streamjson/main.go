@@ -0,0 +1,140 @@
+// streamjson reads a JSON stream from stdin in chunks and prints the completed JSON.
+//
+// Pipe LLM output into it during development, for example:
+//
+//	curl -N https://example.com/stream | streamjson -chunk-size 16 -pretty
+//
+// Or print only the final completion in shell-based tests:
+//
+//	echo -n '{"a":[tr' | streamjson -eof
+//
+// Or replay recorded chunks with timing to debug rendering issues:
+//
+//	streamjson replay -speed 2 testdata/function-call.jsonl
+//
+// Or complete JSON5 input like config files written by LLM:
+//
+//	echo -n "{name: 'streamjson', tags: ['cli',]" | streamjson -relaxed -eof
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	streamingjson "github.com/karminski/streaming-json-go"
+)
+
+// command line options
+type options struct {
+	chunkSize  int
+	onlyEOF    bool
+	onBoundary bool
+	pretty     bool
+	strict     bool
+	relaxed    bool
+	ndjson     bool
+	stacks     bool
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run command with given arguments, returns exit code
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	if len(args) > 0 && args[0] == "replay" {
+		return runReplay(args[1:], stdin, stdout, stderr)
+	}
+	opts := options{}
+	flags := flag.NewFlagSet("streamjson", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.IntVar(&opts.chunkSize, "chunk-size", streamingjson.DEFAULT_SNAPSHOT_READ_SIZE, "bytes read from stdin at most for each chunk")
+	flags.BoolVar(&opts.onlyEOF, "eof", false, "only print the completed JSON at EOF")
+	flags.BoolVar(&opts.onBoundary, "boundary", false, "only print the completed JSON when a value finalized")
+	flags.BoolVar(&opts.pretty, "pretty", false, "pretty-print the completed JSON")
+	flags.BoolVar(&opts.strict, "strict", false, "check JSON stream by JSON grammar, exit with syntax error at the offending byte or the end of incomplete JSON stream")
+	flags.BoolVar(&opts.relaxed, "relaxed", false, "read JSON5 input (comments, single-quoted strings, unquoted keys, trailing commas, hexadecimal numbers, Infinity and NaN as null)")
+	flags.BoolVar(&opts.ndjson, "ndjson", false, "print each completed JSON snapshot compacted in one line (NDJSON)")
+	flags.BoolVar(&opts.stacks, "stacks", false, "print token stack and mirror token stack to stderr for debugging")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if opts.pretty && opts.ndjson {
+		fmt.Fprintln(stderr, "streamjson: -pretty and -ndjson can not be used together")
+		return 2
+	}
+	if err := complete(opts, stdin, stdout, stderr); err != nil {
+		fmt.Fprintf(stderr, "streamjson: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+// read JSON stream and print the completed JSON
+func complete(opts options, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	if opts.relaxed {
+		stdin = newRelaxedReader(stdin)
+	}
+	reader := streamingjson.NewSnapshotReader(stdin, &streamingjson.SnapshotReaderOptions{
+		ReadSize:           opts.chunkSize,
+		SnapshotOnBoundary: opts.onBoundary,
+		Strict:             opts.strict,
+	})
+	writer := bufio.NewWriter(stdout)
+	defer writer.Flush()
+	haveSnapshot := false
+	for {
+		snapshot, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		haveSnapshot = true
+		if opts.onlyEOF {
+			continue
+		}
+		if err := printSnapshot(opts, reader.Lexer(), snapshot, writer, stderr); err != nil {
+			return err
+		}
+		// flush every snapshot for piping
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+	}
+	if opts.onlyEOF && haveSnapshot {
+		return printSnapshot(opts, reader.Lexer(), reader.Lexer().CompleteJSON(), writer, stderr)
+	}
+	return nil
+}
+
+// print completed JSON snapshot in given format
+func printSnapshot(opts options, lexer *streamingjson.Lexer, snapshot string, stdout io.Writer, stderr io.Writer) error {
+	if opts.stacks {
+		fmt.Fprintf(stderr, "token stack: %s\nmirror token stack: %s\n", lexer.DumpTokenStack(), lexer.DumpMirrorTokenStack())
+	}
+	var formatted bytes.Buffer
+	var errInFormat error
+	switch {
+	case opts.pretty:
+		errInFormat = json.Indent(&formatted, []byte(snapshot), "", "  ")
+	case opts.ndjson:
+		errInFormat = json.Compact(&formatted, []byte(snapshot))
+	default:
+		formatted.WriteString(snapshot)
+	}
+	// print as it is if the completed JSON can not be formatted
+	if errInFormat != nil {
+		formatted.Reset()
+		formatted.WriteString(snapshot)
+	}
+	formatted.WriteByte('\n')
+	_, err := stdout.Write(formatted.Bytes())
+	return err
+}
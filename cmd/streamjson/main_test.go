@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func runWithInput(args []string, input string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(input), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_chunks(t *testing.T) {
+	code, stdout, _ := runWithInput([]string{"-chunk-size", "4"}, `{"a":[true]}`)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "{\"a\":null}\n{\"a\":[true]}\n{\"a\":[true]}\n", stdout)
+}
+
+func TestRun_onlyEOF(t *testing.T) {
+	code, stdout, _ := runWithInput([]string{"-chunk-size", "1", "-eof"}, `{"a":[tr`)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "{\"a\":[true]}\n", stdout)
+}
+
+func TestRun_pretty(t *testing.T) {
+	code, stdout, _ := runWithInput([]string{"-eof", "-pretty"}, `{"a":[1,`)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "{\n  \"a\": [\n    1\n  ]\n}\n", stdout)
+}
+
+func TestRun_ndjson(t *testing.T) {
+	code, stdout, _ := runWithInput([]string{"-chunk-size", "6", "-ndjson"}, "{\n \"a\":\n \"b\"}")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "{\"a\":null}\n{\"a\":\"b\"}\n{\"a\":\"b\"}\n", stdout)
+}
+
+func TestRun_stacks(t *testing.T) {
+	code, _, stderr := runWithInput([]string{"-stacks"}, `{"a":1`)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "token stack: { \" \" : NUMBER\nmirror token stack: }\n", stderr)
+}
+
+func TestRun_strict(t *testing.T) {
+	code, _, stderr := runWithInput([]string{"-strict"}, `{"a":1x`)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "streamjson: syntax error at offset 6: invalid character \"x\" after value\n", stderr)
+
+	// incomplete JSON stream is reported at the end of it
+	code, _, stderr = runWithInput([]string{"-strict", "-eof"}, `{"a":[tr`)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "streamjson: syntax error at offset 8: unexpected end of JSON stream\n", stderr)
+
+	code, stdout, _ := runWithInput([]string{"-strict", "-eof"}, `{"a":[true]}`)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "{\"a\":[true]}\n", stdout)
+}
+
+func TestRun_relaxed(t *testing.T) {
+	input := "{// comment\n  name: 'say \"hi\"', /* block */\n  list: [0x1F, .5, +1, 2., -Infinity, NaN,],\n}"
+	code, stdout, _ := runWithInput([]string{"-relaxed", "-eof", "-ndjson"}, input)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "{\"name\":\"say \\\"hi\\\"\",\"list\":[31,0.5,1,2.0,null,null]}\n", stdout)
+
+	// same completion at every chunk boundary
+	for size := 1; size < len(input); size++ {
+		code, chunked, _ := runWithInput([]string{"-relaxed", "-eof", "-ndjson", "-chunk-size", strconv.Itoa(size)}, input)
+		assert.Equal(t, 0, code)
+		assert.Equal(t, stdout, chunked, "chunk size %d", size)
+	}
+
+	// held trailing comma and unquoted key in progress
+	code, stdout, _ = runWithInput([]string{"-relaxed", "-eof"}, "{a: 'b', c")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "{\"a\": \"b\", \"c\":null}\n", stdout)
+}
+
+func TestRun_invalidFlags(t *testing.T) {
+	code, _, _ := runWithInput([]string{"-pretty", "-ndjson"}, `{}`)
+	assert.Equal(t, 2, code)
+}
+
+func TestRunReplay(t *testing.T) {
+	code, stdout, stderr := runWithInput([]string{"replay", "-speed", "0", "-fail-invalid", "testdata/function-call.jsonl"}, ``)
+	assert.Equal(t, 0, code)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	assert.Equal(t, 5, len(lines))
+	assert.Contains(t, lines[4], `"completed":"{\"function_name\": \"run_code\", \"arguments\": \"print(\\\"hello world\\\")\"}","valid":true`)
+	assert.Contains(t, stderr, "replayed 5 chunks, 0 invalid")
+}
+
+func TestRunReplay_failInvalid(t *testing.T) {
+	code, _, stderr := runWithInput([]string{"replay", "-speed", "0", "-fail-invalid"}, `{"t": 0, "chunk": "[1x"}`)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "1 invalid completed JSON")
+}
@@ -0,0 +1,341 @@
+package main
+
+import (
+	"io"
+	"math/big"
+)
+
+// relaxed reader states
+const (
+	RELAXED_STATE_VALUE                  = iota // outside of string, comment, unquoted key and number
+	RELAXED_STATE_SLASH                         // "/" which may start a comment
+	RELAXED_STATE_LINE_COMMENT                  // in "// ..." comment
+	RELAXED_STATE_BLOCK_COMMENT                 // in "/* ... */" comment
+	RELAXED_STATE_BLOCK_COMMENT_STAR            // "*" in block comment which may end it
+	RELAXED_STATE_STRING                        // in string quoted by '"' or '\''
+	RELAXED_STATE_STRING_ESCAPE                 // "\" in string
+	RELAXED_STATE_STRING_CARRIAGE_RETURN        // "\" and "\r" in string, "\n" may follow as the same line continuation
+	RELAXED_STATE_KEY                           // in unquoted object key
+	RELAXED_STATE_SIGN                          // "+" or "-" before number, Infinity or NaN
+	RELAXED_STATE_ZERO                          // leading "0" which may start hexadecimal number
+	RELAXED_STATE_HEX                           // in hexadecimal number
+	RELAXED_STATE_NUMBER                        // in decimal number
+	RELAXED_STATE_NAN_OR_INFINITY               // in Infinity or NaN, written as null
+)
+
+// relaxed reader translates JSON5 input (comments, single-quoted strings, unquoted keys, trailing commas,
+// hexadecimal numbers, leading and trailing decimal points, explicit plus sign, Infinity and NaN) into JSON stream.
+// the bytes which can't be decided alone (sign, leading zero, comma, hexadecimal digits) are held until the next byte arrives.
+// Infinity and NaN are written as null, since JSON can't represent them.
+type relaxedReader struct {
+	reader       io.Reader
+	buffer       []byte
+	translated   []byte // translated JSON stream not read yet
+	offset       int    // offset of translated JSON stream read
+	state        int    // RELAXED_STATE_*
+	quote        byte   // quote of current string
+	sign         byte   // held sign before number
+	afterDot     bool   // number stopped right after decimal point, "0" will be written if no digit follows
+	hexDigits    []byte // held hexadecimal digits
+	containers   []byte // "{" and "[" of open containers
+	expectKey    bool   // next value is object key
+	pendingComma bool   // comma held until the next token, dropped before "}" and "]"
+	pendingSpace []byte // whitespace after held comma
+	err          error  // sticky error from underlying reader
+}
+
+// new relaxed reader reads JSON5 input from r
+func newRelaxedReader(r io.Reader) *relaxedReader {
+	return &relaxedReader{reader: r}
+}
+
+// read translated JSON stream
+func (relaxed *relaxedReader) Read(p []byte) (int, error) {
+	for relaxed.offset == len(relaxed.translated) && relaxed.err == nil {
+		relaxed.translated = relaxed.translated[:0]
+		relaxed.offset = 0
+		if cap(relaxed.buffer) < len(p) {
+			relaxed.buffer = make([]byte, len(p))
+		}
+		n, err := relaxed.reader.Read(relaxed.buffer[:len(p)])
+		for _, c := range relaxed.buffer[:n] {
+			relaxed.translate(c)
+		}
+		if err != nil {
+			if err == io.EOF {
+				relaxed.finish()
+			}
+			relaxed.err = err
+		}
+		// leave empty read to caller
+		if n == 0 {
+			break
+		}
+	}
+	if relaxed.offset == len(relaxed.translated) {
+		return 0, relaxed.err
+	}
+	n := copy(p, relaxed.translated[relaxed.offset:])
+	relaxed.offset += n
+	return n, nil
+}
+
+// write held bytes at the end of input
+func (relaxed *relaxedReader) finish() {
+	switch relaxed.state {
+	case RELAXED_STATE_SLASH:
+		relaxed.writeToken('/')
+	case RELAXED_STATE_SIGN:
+		if relaxed.sign == '-' {
+			relaxed.translated = append(relaxed.translated, '-')
+		}
+	case RELAXED_STATE_ZERO:
+		relaxed.translated = append(relaxed.translated, '0')
+	case RELAXED_STATE_HEX:
+		relaxed.writeHexNumber()
+	case RELAXED_STATE_NUMBER:
+		if relaxed.afterDot {
+			relaxed.translated = append(relaxed.translated, '0')
+		}
+	}
+	if relaxed.pendingComma {
+		relaxed.translated = append(relaxed.translated, ',')
+		relaxed.translated = append(relaxed.translated, relaxed.pendingSpace...)
+	}
+	relaxed.state = RELAXED_STATE_VALUE
+}
+
+// translate one byte of input
+func (relaxed *relaxedReader) translate(c byte) {
+	switch relaxed.state {
+	case RELAXED_STATE_VALUE:
+		relaxed.translateValue(c)
+	case RELAXED_STATE_SLASH:
+		switch c {
+		case '/':
+			relaxed.state = RELAXED_STATE_LINE_COMMENT
+		case '*':
+			relaxed.state = RELAXED_STATE_BLOCK_COMMENT
+		default:
+			relaxed.writeToken('/')
+			relaxed.state = RELAXED_STATE_VALUE
+			relaxed.translateValue(c)
+		}
+	case RELAXED_STATE_LINE_COMMENT:
+		if c == '\n' || c == '\r' {
+			relaxed.state = RELAXED_STATE_VALUE
+			relaxed.translateValue(c)
+		}
+	case RELAXED_STATE_BLOCK_COMMENT:
+		if c == '*' {
+			relaxed.state = RELAXED_STATE_BLOCK_COMMENT_STAR
+		}
+	case RELAXED_STATE_BLOCK_COMMENT_STAR:
+		switch c {
+		case '/':
+			relaxed.state = RELAXED_STATE_VALUE
+		case '*':
+		default:
+			relaxed.state = RELAXED_STATE_BLOCK_COMMENT
+		}
+	case RELAXED_STATE_STRING:
+		switch {
+		case c == relaxed.quote:
+			relaxed.translated = append(relaxed.translated, '"')
+			relaxed.state = RELAXED_STATE_VALUE
+		case c == '"':
+			relaxed.translated = append(relaxed.translated, '\\', '"')
+		case c == '\\':
+			relaxed.state = RELAXED_STATE_STRING_ESCAPE
+		default:
+			relaxed.translated = append(relaxed.translated, c)
+		}
+	case RELAXED_STATE_STRING_ESCAPE:
+		relaxed.state = RELAXED_STATE_STRING
+		relaxed.translateEscape(c)
+	case RELAXED_STATE_STRING_CARRIAGE_RETURN:
+		relaxed.state = RELAXED_STATE_STRING
+		if c != '\n' {
+			relaxed.translate(c)
+		}
+	case RELAXED_STATE_KEY:
+		if isIdentifierByte(c) {
+			relaxed.translated = append(relaxed.translated, c)
+			return
+		}
+		relaxed.translated = append(relaxed.translated, '"')
+		relaxed.state = RELAXED_STATE_VALUE
+		relaxed.translateValue(c)
+	case RELAXED_STATE_SIGN:
+		if c == 'I' || c == 'N' {
+			relaxed.translated = append(relaxed.translated, "null"...)
+			relaxed.state = RELAXED_STATE_NAN_OR_INFINITY
+			return
+		}
+		if relaxed.sign == '-' {
+			relaxed.translated = append(relaxed.translated, '-')
+		}
+		relaxed.state = RELAXED_STATE_VALUE
+		relaxed.translateValue(c)
+	case RELAXED_STATE_ZERO:
+		if c == 'x' || c == 'X' {
+			relaxed.hexDigits = relaxed.hexDigits[:0]
+			relaxed.state = RELAXED_STATE_HEX
+			return
+		}
+		relaxed.translated = append(relaxed.translated, '0')
+		relaxed.state = RELAXED_STATE_NUMBER
+		relaxed.translate(c)
+	case RELAXED_STATE_HEX:
+		if isHexByte(c) {
+			relaxed.hexDigits = append(relaxed.hexDigits, c)
+			return
+		}
+		relaxed.writeHexNumber()
+		relaxed.state = RELAXED_STATE_VALUE
+		relaxed.translateValue(c)
+	case RELAXED_STATE_NUMBER:
+		isDigit := c >= '0' && c <= '9'
+		if relaxed.afterDot && !isDigit {
+			relaxed.translated = append(relaxed.translated, '0')
+		}
+		relaxed.afterDot = c == '.'
+		if isDigit || c == '.' || c == 'e' || c == 'E' || c == '+' || c == '-' {
+			relaxed.translated = append(relaxed.translated, c)
+			return
+		}
+		relaxed.state = RELAXED_STATE_VALUE
+		relaxed.translateValue(c)
+	case RELAXED_STATE_NAN_OR_INFINITY:
+		if !isIdentifierByte(c) {
+			relaxed.state = RELAXED_STATE_VALUE
+			relaxed.translateValue(c)
+		}
+	}
+}
+
+// translate one byte outside of string, comment, unquoted key and number
+func (relaxed *relaxedReader) translateValue(c byte) {
+	switch {
+	case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+		if relaxed.pendingComma {
+			relaxed.pendingSpace = append(relaxed.pendingSpace, c)
+			return
+		}
+		relaxed.translated = append(relaxed.translated, c)
+	case c == '/':
+		relaxed.state = RELAXED_STATE_SLASH
+	case c == ',':
+		relaxed.writePendingComma()
+		relaxed.pendingComma = true
+		relaxed.expectKey = len(relaxed.containers) > 0 && relaxed.containers[len(relaxed.containers)-1] == '{'
+	case c == '}' || c == ']':
+		// trailing comma
+		if relaxed.pendingComma {
+			relaxed.translated = append(relaxed.translated, relaxed.pendingSpace...)
+			relaxed.pendingComma = false
+			relaxed.pendingSpace = relaxed.pendingSpace[:0]
+		}
+		if len(relaxed.containers) > 0 {
+			relaxed.containers = relaxed.containers[:len(relaxed.containers)-1]
+		}
+		relaxed.expectKey = false
+		relaxed.translated = append(relaxed.translated, c)
+	default:
+		relaxed.writeToken(c)
+	}
+}
+
+// write token byte after held comma, and start string, unquoted key or number by it
+func (relaxed *relaxedReader) writeToken(c byte) {
+	relaxed.writePendingComma()
+	switch {
+	case c == '{' || c == '[':
+		relaxed.containers = append(relaxed.containers, c)
+		relaxed.expectKey = c == '{'
+		relaxed.translated = append(relaxed.translated, c)
+	case c == ':':
+		relaxed.expectKey = false
+		relaxed.translated = append(relaxed.translated, c)
+	case c == '"' || c == '\'':
+		relaxed.quote = c
+		relaxed.state = RELAXED_STATE_STRING
+		relaxed.translated = append(relaxed.translated, '"')
+	case relaxed.expectKey && isIdentifierByte(c):
+		relaxed.state = RELAXED_STATE_KEY
+		relaxed.translated = append(relaxed.translated, '"', c)
+	case c == '+' || c == '-':
+		relaxed.sign = c
+		relaxed.state = RELAXED_STATE_SIGN
+	case c == '0':
+		relaxed.state = RELAXED_STATE_ZERO
+	case c >= '1' && c <= '9':
+		relaxed.afterDot = false
+		relaxed.state = RELAXED_STATE_NUMBER
+		relaxed.translated = append(relaxed.translated, c)
+	case c == '.':
+		// leading decimal point
+		relaxed.afterDot = true
+		relaxed.state = RELAXED_STATE_NUMBER
+		relaxed.translated = append(relaxed.translated, '0', '.')
+	case c == 'I' || c == 'N':
+		relaxed.state = RELAXED_STATE_NAN_OR_INFINITY
+		relaxed.translated = append(relaxed.translated, "null"...)
+	default:
+		relaxed.translated = append(relaxed.translated, c)
+	}
+}
+
+// translate escaped character in string
+func (relaxed *relaxedReader) translateEscape(c byte) {
+	switch c {
+	case '"', '\\', '/', 'b', 'f', 'n', 'r', 't', 'u':
+		relaxed.translated = append(relaxed.translated, '\\', c)
+	case '\'':
+		relaxed.translated = append(relaxed.translated, '\'')
+	case 'v':
+		relaxed.translated = append(relaxed.translated, `\u000b`...)
+	case '0':
+		relaxed.translated = append(relaxed.translated, `\u0000`...)
+	case 'x':
+		// the 2 hexadecimal digits follow as they are
+		relaxed.translated = append(relaxed.translated, `\u00`...)
+	case '\n':
+		// line continuation
+	case '\r':
+		relaxed.state = RELAXED_STATE_STRING_CARRIAGE_RETURN
+	default:
+		relaxed.translated = append(relaxed.translated, c)
+	}
+}
+
+// write held comma and whitespace after it
+func (relaxed *relaxedReader) writePendingComma() {
+	if !relaxed.pendingComma {
+		return
+	}
+	relaxed.translated = append(relaxed.translated, ',')
+	relaxed.translated = append(relaxed.translated, relaxed.pendingSpace...)
+	relaxed.pendingComma = false
+	relaxed.pendingSpace = relaxed.pendingSpace[:0]
+}
+
+// write held hexadecimal number in decimal
+func (relaxed *relaxedReader) writeHexNumber() {
+	number, ok := new(big.Int).SetString(string(relaxed.hexDigits), 16)
+	if !ok {
+		relaxed.translated = append(relaxed.translated, '0')
+		return
+	}
+	relaxed.translated = number.Append(relaxed.translated, 10)
+}
+
+// byte can be part of unquoted key
+func isIdentifierByte(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' || c == '$' || c == '\\' || c >= 0x80
+}
+
+// byte is hexadecimal digit
+func isHexByte(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	streamingjson "github.com/karminski/streaming-json-go"
+)
+
+// replayed chunk result printed in one line
+type replayLine struct {
+	Index     int     `json:"index"`
+	T         float64 `json:"t"`
+	Chunk     string  `json:"chunk"`
+	Completed string  `json:"completed"`
+	Valid     bool    `json:"valid"`
+	LatencyNS int64   `json:"latency_ns"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// run replay subcommand with given arguments, returns exit code
+func runReplay(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	var speed float64
+	var failInvalid bool
+	flags := flag.NewFlagSet("streamjson replay", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: streamjson replay [flags] [fixture.jsonl]")
+		fmt.Fprintln(stderr, "replay recorded JSON Lines chunks of `{\"t\": milliseconds, \"chunk\": \"...\"}`, read from stdin if no fixture given")
+		flags.PrintDefaults()
+	}
+	flags.Float64Var(&speed, "speed", 1, "timing acceleration, 1 for original timing, 0 for no waiting")
+	flags.BoolVar(&failInvalid, "fail-invalid", false, "exit with error when any completed JSON is invalid for encoding/json")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	fixture := stdin
+	if flags.NArg() > 0 {
+		file, err := os.Open(flags.Arg(0))
+		if err != nil {
+			fmt.Fprintf(stderr, "streamjson: %s\n", err)
+			return 1
+		}
+		defer file.Close()
+		fixture = file
+	}
+	if err := replay(fixture, speed, failInvalid, stdout, stderr); err != nil {
+		fmt.Fprintf(stderr, "streamjson: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+// replay fixture and print every replayed chunk as JSON Lines, the summary will be printed to stderr
+func replay(fixture io.Reader, speed float64, failInvalid bool, stdout io.Writer, stderr io.Writer) error {
+	chunks, err := streamingjson.ReadReplayChunks(fixture)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(stdout)
+	defer writer.Flush()
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	invalid := 0
+	var totalLatency, maxLatency time.Duration
+	errInReplay := streamingjson.Replay(chunks, &streamingjson.ReplayOptions{Speed: speed}, func(result streamingjson.ReplayResult) error {
+		line := replayLine{
+			Index:     result.Index,
+			T:         result.T,
+			Chunk:     result.Chunk,
+			Completed: result.Completed,
+			Valid:     result.Valid,
+			LatencyNS: result.Latency.Nanoseconds(),
+		}
+		if result.Err != nil {
+			line.Error = result.Err.Error()
+		}
+		if !result.Valid || result.Err != nil {
+			invalid++
+		}
+		totalLatency += result.Latency
+		if result.Latency > maxLatency {
+			maxLatency = result.Latency
+		}
+		if err := encoder.Encode(line); err != nil {
+			return err
+		}
+		return writer.Flush()
+	})
+	if errInReplay != nil {
+		return errInReplay
+	}
+	averageLatency := time.Duration(0)
+	if len(chunks) > 0 {
+		averageLatency = totalLatency / time.Duration(len(chunks))
+	}
+	fmt.Fprintf(stderr, "replayed %d chunks, %d invalid, average latency %s, max latency %s\n", len(chunks), invalid, averageLatency, maxLatency)
+	if failInvalid && invalid > 0 {
+		return fmt.Errorf("%d invalid completed JSON", invalid)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package streamingjsongo
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// decoder of JSON stream in the shape of json.Decoder, Token(), More() and Decode() work on the JSON stream appended to lexer so far.
+// ErrNeedMoreData is returned instead of io.ErrUnexpectedEOF if the next token or value is not complete yet,
+// nothing is consumed then, so call it again after appending more JSON segment.
+type Decoder struct {
+	lexer                 *Lexer
+	tokenizer             *Tokenizer
+	useNumber             bool
+	disallowUnknownFields bool
+	value                 valueScanner // the container value in progress of Decode()
+}
+
+// scanner finding the end of container value in JSON content, the scanning is resumed when more JSON content arrives
+type valueScanner struct {
+	start    int // offset of the value in JSONContent, -1 if no value in progress
+	scanned  int // length of JSON content scanned
+	depth    int
+	inString bool
+	escaped  bool
+}
+
+// get decoder of JSON stream from the start
+func (lexer *Lexer) Decoder() *Decoder {
+	return &Decoder{lexer: lexer, tokenizer: lexer.Tokenizer(), value: valueScanner{start: -1}}
+}
+
+// decode number into json.Number instead of float64, like json.Decoder.UseNumber()
+func (decoder *Decoder) UseNumber() {
+	decoder.useNumber = true
+}
+
+// return error when decoding object with unknown key, like json.Decoder.DisallowUnknownFields()
+func (decoder *Decoder) DisallowUnknownFields() {
+	decoder.disallowUnknownFields = true
+}
+
+// get offset of decoder position in the appended JSON stream
+func (decoder *Decoder) InputOffset() int64 {
+	return int64(decoder.lexer.sourceMap.streamOffset(decoder.tokenizer.scanned))
+}
+
+// get the next JSON token like json.Decoder.Token(), which is json.Delim for `[ ] { }`, bool, float64 (or json.Number), string or nil.
+// the partial token is not returned, ErrNeedMoreData is returned until it completes.
+func (decoder *Decoder) Token() (json.Token, error) {
+	token, err := decoder.tokenizer.Next()
+	for err == nil && token.Partial {
+		token, err = decoder.tokenizer.Next()
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch token.Kind {
+	case TOKEN_KIND_BEGIN_OBJECT, TOKEN_KIND_END_OBJECT, TOKEN_KIND_BEGIN_ARRAY, TOKEN_KIND_END_ARRAY:
+		return json.Delim(token.Raw[0]), nil
+	case TOKEN_KIND_KEY, TOKEN_KIND_STRING:
+		return token.Value, nil
+	case TOKEN_KIND_NUMBER:
+		if decoder.useNumber {
+			return json.Number(token.Raw), nil
+		}
+		number, err := strconv.ParseFloat(token.Raw, 64)
+		if err != nil {
+			return nil, &SyntaxError{Offset: token.Offset, Message: "invalid number " + token.Raw}
+		}
+		return number, nil
+	case TOKEN_KIND_BOOL:
+		return token.Raw == "true", nil
+	}
+	return nil, nil
+}
+
+// report whether there is another element in the current array or object like json.Decoder.More().
+// true if the next token did not arrive yet, so the following Token() or Decode() returns ErrNeedMoreData.
+func (decoder *Decoder) More() bool {
+	if decoder.lexer.flush.writer != nil {
+		// Token() or Decode() reports ErrContentFlushed
+		return true
+	}
+	tokenizer := decoder.tokenizer
+	content := decoder.lexer.JSONContent.String()
+	for i := tokenizer.scanned; i < len(content); i++ {
+		if c := content[i]; !isIgnoreToken(c) && c != TOKEN_COMMA_SYMBOL && c != TOKEN_COLON_SYMBOL {
+			return c != TOKEN_RIGHT_BRACE_SYMBOL && c != TOKEN_RIGHT_BRACKET_SYMBOL
+		}
+	}
+	// nothing more after the root value
+	return len(tokenizer.containers) > 0 || tokenizer.scanned == 0
+}
+
+// decode the next complete JSON value into v like json.Decoder.Decode()
+func (decoder *Decoder) Decode(v interface{}) error {
+	if decoder.lexer.flush.writer != nil {
+		return ErrContentFlushed
+	}
+	tokenizer := decoder.tokenizer
+	content := decoder.lexer.JSONContent.String()
+	start := tokenizer.scanned
+	for start < len(content) && (isIgnoreToken(content[start]) || content[start] == TOKEN_COMMA_SYMBOL || content[start] == TOKEN_COLON_SYMBOL) {
+		start++
+	}
+	if start == len(content) {
+		return ErrNeedMoreData
+	}
+
+	// scalar value is a token
+	if c := content[start]; c != TOKEN_LEFT_BRACE_SYMBOL && c != TOKEN_LEFT_BRACKET_SYMBOL {
+		token, err := tokenizer.Next()
+		for err == nil && token.Partial {
+			token, err = tokenizer.Next()
+		}
+		if err != nil {
+			return err
+		}
+		return decoder.unmarshal(token.Raw, v)
+	}
+
+	// container value waits for its closer
+	if decoder.value.start != start {
+		decoder.value = valueScanner{start: start, scanned: start}
+	}
+	end, complete := decoder.value.scan(content)
+	if !complete {
+		return ErrNeedMoreData
+	}
+	decoder.value.start = -1
+	tokenizer.skipTo(end)
+	return decoder.unmarshal(content[start:end], v)
+}
+
+// unmarshal raw JSON value into v with decoder options
+func (decoder *Decoder) unmarshal(raw string, v interface{}) error {
+	jsonDecoder := json.NewDecoder(strings.NewReader(raw))
+	if decoder.useNumber {
+		jsonDecoder.UseNumber()
+	}
+	if decoder.disallowUnknownFields {
+		jsonDecoder.DisallowUnknownFields()
+	}
+	return jsonDecoder.Decode(v)
+}
+
+// scan JSON content appended since last scan, returns the end offset of container value if it is complete
+func (scanner *valueScanner) scan(content string) (int, bool) {
+	for ; scanner.scanned < len(content); scanner.scanned++ {
+		c := content[scanner.scanned]
+		if scanner.inString {
+			switch {
+			case scanner.escaped:
+				scanner.escaped = false
+			case c == TOKEN_ESCAPE_CHARACTER_SYMBOL:
+				scanner.escaped = true
+			case c == TOKEN_QUOTE_SYMBOL:
+				scanner.inString = false
+			}
+			continue
+		}
+		switch c {
+		case TOKEN_QUOTE_SYMBOL:
+			scanner.inString = true
+		case TOKEN_LEFT_BRACE_SYMBOL, TOKEN_LEFT_BRACKET_SYMBOL:
+			scanner.depth++
+		case TOKEN_RIGHT_BRACE_SYMBOL, TOKEN_RIGHT_BRACKET_SYMBOL:
+			scanner.depth--
+			if scanner.depth == 0 {
+				scanner.scanned++
+				return scanner.scanned, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// move tokenizer to given offset in JSONContent after a value skipped
+func (tokenizer *Tokenizer) skipTo(end int) {
+	tokenizer.scanned = end
+	tokenizer.expectingKey = false
+	tokenizer.partialLen = 0
+	tokenizer.stringEnd = 0
+}
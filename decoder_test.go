@@ -0,0 +1,150 @@
+package streamingjsongo
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoder_Token(t *testing.T) {
+	document := `{"a\nb": [1, -2.5e+3, "x\"y你"], "c": {"d": true, "e": null}, "f": [false, {}]}`
+	var expected []json.Token
+	jsonDecoder := json.NewDecoder(strings.NewReader(document))
+	for {
+		token, err := jsonDecoder.Token()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		expected = append(expected, token)
+	}
+
+	// append byte by byte, the tokens are the same as json.Decoder
+	lexer := NewLexer()
+	decoder := lexer.Decoder()
+	var tokens []json.Token
+	for i := 0; i < len(document); i++ {
+		lexer.AppendString(document[i : i+1])
+		for {
+			token, err := decoder.Token()
+			if err == ErrNeedMoreData {
+				break
+			}
+			assert.Nil(t, err)
+			tokens = append(tokens, token)
+		}
+	}
+	assert.Equal(t, expected, tokens)
+	assert.False(t, decoder.More())
+}
+
+func TestDecoder_Decode(t *testing.T) {
+	type item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	document := `{"items": [{"id": 1, "name": "a]"}, {"id": 2, "name": "b"}], "total": 2}`
+	lexer := NewLexer()
+	decoder := lexer.Decoder()
+
+	// the code of json.Decoder, retried after appending more JSON segment when ErrNeedMoreData returned
+	var items []item
+	var total int
+	var key json.Token
+	step := 0
+	run := func() error {
+		for {
+			switch step {
+			case 0, 1, 2, 4, 5, 7:
+				token, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+				if step == 5 {
+					key = token
+				}
+			case 3:
+				if !decoder.More() {
+					break
+				}
+				var element item
+				if err := decoder.Decode(&element); err != nil {
+					return err
+				}
+				items = append(items, element)
+				continue
+			case 6:
+				if err := decoder.Decode(&total); err != nil {
+					return err
+				}
+			default:
+				return nil
+			}
+			step++
+		}
+	}
+	for _, chunk := range splitIntoChunks(document, 3) {
+		lexer.AppendString(chunk)
+		if err := run(); err != ErrNeedMoreData {
+			assert.Nil(t, err)
+		}
+	}
+	assert.Nil(t, run())
+	assert.Equal(t, []item{{1, "a]"}, {2, "b"}}, items)
+	assert.Equal(t, "total", key)
+	assert.Equal(t, 2, total)
+}
+
+func TestDecoder_needMoreData(t *testing.T) {
+	lexer := NewLexer()
+	decoder := lexer.Decoder()
+	assert.True(t, decoder.More())
+	lexer.AppendString(`[{"a": 1`)
+	token, err := decoder.Token()
+	assert.Equal(t, json.Delim('['), token)
+	assert.Nil(t, err)
+	assert.True(t, decoder.More())
+
+	// nothing is consumed when ErrNeedMoreData returned
+	var value map[string]int
+	assert.Equal(t, ErrNeedMoreData, decoder.Decode(&value))
+	lexer.AppendString(`}, 12`)
+	assert.Nil(t, decoder.Decode(&value))
+	assert.Equal(t, map[string]int{"a": 1}, value)
+	assert.Equal(t, int64(9), decoder.InputOffset())
+	_, err = decoder.Token()
+	assert.Equal(t, ErrNeedMoreData, err)
+	lexer.AppendString(`3]`)
+	token, err = decoder.Token()
+	assert.Equal(t, float64(123), token)
+	assert.Nil(t, err)
+	assert.False(t, decoder.More())
+	token, err = decoder.Token()
+	assert.Equal(t, json.Delim(']'), token)
+	assert.Nil(t, err)
+	assert.False(t, decoder.More())
+	_, err = decoder.Token()
+	assert.Equal(t, ErrNeedMoreData, err)
+}
+
+func TestDecoder_options(t *testing.T) {
+	lexer := NewLexer()
+	decoder := lexer.Decoder()
+	decoder.UseNumber()
+	decoder.DisallowUnknownFields()
+	lexer.AppendString(`[1.50, {"b": 1}, {"a": 2}]`)
+	token, _ := decoder.Token()
+	assert.Equal(t, json.Delim('['), token)
+	token, _ = decoder.Token()
+	assert.Equal(t, json.Number("1.50"), token)
+
+	var value struct {
+		A json.Number `json:"a"`
+	}
+	assert.Equal(t, `json: unknown field "b"`, decoder.Decode(&value).Error())
+	assert.Nil(t, decoder.Decode(&value))
+	assert.Equal(t, json.Number("2"), value.A)
+}
@@ -6,11 +6,76 @@ import (
 )
 
 type Lexer struct {
-	JSONContent      strings.Builder // input JSON content
-	PaddingContent   strings.Builder // padding content for ignored characters and escape characters, etc.
-	JSONSegment      string          // appended JSON segment by the AppendString() method.
-	TokenStack       []int           // token stack for input JSON
-	MirrorTokenStack []int           // token stack for auto-completed tokens
+	JSONContent         strings.Builder      // input JSON content
+	PaddingContent      strings.Builder      // padding content for ignored characters and escape characters, etc., updated after each AppendString()
+	JSONSegment         string               // appended JSON segment by the AppendString() method.
+	TokenStack          []int                // token stack for input JSON, the last 8 tokens after each AppendString() for debugging
+	MirrorTokenStack    []int                // token stack for auto-completed tokens
+	paddingContent      []byte               // padding content while appending, copied into PaddingContent after each AppendString()
+	state               int                  // state of JSON stream cursor, STREAM_STATE_*
+	finalizedValues     int                  // count of finalized values (string, number, literal, object, array) in JSON stream
+	number              numberState          // number on JSON stream cursor
+	pathFrames          []pathFrame          // container frames on the path of JSON stream cursor
+	lastClosedPathFrame pathFrame            // the container frame closed last time
+	rootStarted         bool                 // root container of JSON stream started
+	patchBase           patchBase            // completion state at last patch generated
+	schema              *Schema              // schema for guiding completion, nil if no schema attached
+	validation          schemaValidation     // state of incremental schema validation
+	strict              grammarChecker       // state of grammar checking in strict mode
+	indentedContent     formattedJSONContent // JSON content indented by CompleteJSONIndent()
+	compactContent      formattedJSONContent // JSON content compacted by CompleteJSONCompact()
+	nodeTree            nodeTree             // value tree built from JSON content by Root()
+	streamLength        int                  // length of JSON stream consumed
+	sourceMap           sourceMap            // map between JSON content and JSON stream for SourceOffset()
+	flush               contentFlush         // flushing of JSON content in bounded-memory mode
+}
+
+// the lexer is driven by its state, so only the recent tokens are kept in token stack for debugging,
+// it is trimmed to TOKEN_STACK_KEPT_LENGTH tokens when it reaches TOKEN_STACK_CAPACITY and after each AppendString()
+const (
+	TOKEN_STACK_KEPT_LENGTH = 8
+	TOKEN_STACK_CAPACITY    = 64
+)
+
+// initial capacity of mirror stack and container frames, a new lexer does not grow them until the JSON is nested deep
+const (
+	MIRROR_TOKEN_STACK_INITIAL_CAPACITY = 32
+	PATH_FRAMES_INITIAL_CAPACITY        = 4
+)
+
+// byte to token lookup table, the bytes out of JSON grammar are TOKEN_OTHERS
+var byteTokenTable = newByteTokenTable()
+
+// placeholders pushed into mirror stack, from bottom to top
+var (
+	// `"`, `:`, `n`, `u`, `l`, `l` after properity key start
+	MIRROR_PLACEHOLDER_OBJECT_KEY = []int{TOKEN_ALPHABET_LOWERCASE_L, TOKEN_ALPHABET_LOWERCASE_L, TOKEN_ALPHABET_LOWERCASE_U, TOKEN_ALPHABET_LOWERCASE_N, TOKEN_COLON, TOKEN_QUOTE}
+	// `r`, `u`, `e` after `t`
+	MIRROR_PLACEHOLDER_TRUE = []int{TOKEN_ALPHABET_LOWERCASE_E, TOKEN_ALPHABET_LOWERCASE_U, TOKEN_ALPHABET_LOWERCASE_R}
+	// `a`, `l`, `s`, `e` after `f`
+	MIRROR_PLACEHOLDER_FALSE = []int{TOKEN_ALPHABET_LOWERCASE_E, TOKEN_ALPHABET_LOWERCASE_S, TOKEN_ALPHABET_LOWERCASE_L, TOKEN_ALPHABET_LOWERCASE_A}
+	// `u`, `l`, `l` after `n`
+	MIRROR_PLACEHOLDER_NULL = []int{TOKEN_ALPHABET_LOWERCASE_L, TOKEN_ALPHABET_LOWERCASE_L, TOKEN_ALPHABET_LOWERCASE_U}
+)
+
+// length of properity value placeholder `null` in mirror stack
+const MIRROR_PLACEHOLDER_OBJECT_VALUE_LENGTH = 4
+
+// new byte to token lookup table from token symbols
+func newByteTokenTable() [256]uint8 {
+	var table [256]uint8
+	for i := range table {
+		table[i] = TOKEN_OTHERS
+		if isIgnoreToken(byte(i)) {
+			table[i] = TOKEN_IGNORED
+		}
+	}
+	for token, symbol := range tokenSymbolMap {
+		if len(symbol) == 1 {
+			table[symbol[0]] = uint8(token)
+		}
+	}
+	return table
 }
 
 // new lexer for streaming JSON input
@@ -18,15 +83,6 @@ func NewLexer() *Lexer {
 	return &Lexer{}
 }
 
-// get token on the stack top
-func (lexer *Lexer) getTopTokenOnStack() int {
-	tokenStackLen := len(lexer.TokenStack)
-	if tokenStackLen == 0 {
-		return TOKEN_EOF
-	}
-	return lexer.TokenStack[tokenStackLen-1]
-}
-
 // get token on the mirror stack top
 func (lexer *Lexer) getTopTokenOnMirrorStack() int {
 	mirrotTokenStackLen := len(lexer.MirrorTokenStack)
@@ -58,16 +114,49 @@ func (lexer *Lexer) popMirrorTokenStack() int {
 	return token
 }
 
+// pop n tokens on the mirror stack top
+func (lexer *Lexer) popMirrorTokens(n int) {
+	mirrorTokenStackLen := len(lexer.MirrorTokenStack)
+	if n > mirrorTokenStackLen {
+		n = mirrorTokenStackLen
+	}
+	lexer.MirrorTokenStack = lexer.MirrorTokenStack[:mirrorTokenStackLen-n]
+}
+
 // push token into the stack
 func (lexer *Lexer) pushTokenStack(token int) {
+	if len(lexer.TokenStack) == cap(lexer.TokenStack) {
+		lexer.growTokenStack()
+	}
 	lexer.TokenStack = append(lexer.TokenStack, token)
 }
 
+// make room in full token stack, it is trimmed if it reaches TOKEN_STACK_CAPACITY, or allocated with TOKEN_STACK_CAPACITY
+func (lexer *Lexer) growTokenStack() {
+	if len(lexer.TokenStack) >= TOKEN_STACK_CAPACITY {
+		lexer.trimTokenStack()
+		return
+	}
+	lexer.TokenStack = append(make([]int, 0, TOKEN_STACK_CAPACITY), lexer.TokenStack...)
+}
+
+// keep the last TOKEN_STACK_KEPT_LENGTH tokens in token stack
+func (lexer *Lexer) trimTokenStack() {
+	if n := len(lexer.TokenStack); n > TOKEN_STACK_KEPT_LENGTH {
+		lexer.TokenStack = append(lexer.TokenStack[:0], lexer.TokenStack[n-TOKEN_STACK_KEPT_LENGTH:]...)
+	}
+}
+
 // push token into the mirror stack
 func (lexer *Lexer) pushMirrorTokenStack(token int) {
 	lexer.MirrorTokenStack = append(lexer.MirrorTokenStack, token)
 }
 
+// push placeholder tokens into the mirror stack, from bottom to top
+func (lexer *Lexer) pushMirrorTokens(tokens []int) {
+	lexer.MirrorTokenStack = append(lexer.MirrorTokenStack, tokens...)
+}
+
 // convert mirror stack token into string
 func (lexer *Lexer) dumpMirrorTokenStackToString() string {
 	var stackInString strings.Builder
@@ -77,1084 +166,525 @@ func (lexer *Lexer) dumpMirrorTokenStackToString() string {
 	return stackInString.String()
 }
 
-// skip JSON segment by length n
-func (lexer *Lexer) skipJSONSegment(n int) {
-	lexer.JSONSegment = lexer.JSONSegment[n:]
+// convert token stack into readable string from bottom to top, for debugging
+func (lexer *Lexer) DumpTokenStack() string {
+	return dumpTokensToReadableString(lexer.TokenStack)
+}
+
+// convert mirror stack into readable string from bottom to top, for debugging
+func (lexer *Lexer) DumpMirrorTokenStack() string {
+	return dumpTokensToReadableString(lexer.MirrorTokenStack)
 }
 
-// push negative symbol `-` into JSON content
-func (lexer *Lexer) pushNegativeIntoJSONContent() {
-	lexer.JSONContent.WriteByte(TOKEN_NEGATIVE_SYMBOL)
+// convert tokens into readable string separated by space
+func dumpTokensToReadableString(tokens []int) string {
+	var tokensInString strings.Builder
+	for i, token := range tokens {
+		if i > 0 {
+			tokensInString.WriteByte(' ')
+		}
+		tokensInString.WriteString(TokenName(token))
+	}
+	return tokensInString.String()
 }
 
 // push byte into JSON content by given
 func (lexer *Lexer) pushByteIntoPaddingContent(b byte) {
-	lexer.PaddingContent.WriteByte(b)
+	lexer.paddingContent = append(lexer.paddingContent, b)
 }
 
 // append padding content into JSON content
 func (lexer *Lexer) appendPaddingContentToJSONContent() {
-	lexer.JSONContent.WriteString(lexer.PaddingContent.String())
+	lexer.JSONContent.Write(lexer.paddingContent)
 }
 
 // check if padding content is empty
 func (lexer *Lexer) havePaddingContent() bool {
-	return lexer.PaddingContent.Len() > 0
+	return len(lexer.paddingContent) > 0
 }
 
-// set padding content to empty
+// set padding content to empty, the buffer is kept for reuse
 func (lexer *Lexer) cleanPaddingContent() {
-	lexer.PaddingContent.Reset()
+	lexer.paddingContent = lexer.paddingContent[:0]
 }
 
-// lexer match JSON token method, convert JSON segment to JSON token
-func (lexer *Lexer) matchToken() (int, byte) {
-	// segment end
-	if len(lexer.JSONSegment) == 0 {
-		return TOKEN_EOF, byte(0)
+// append padding content into JSON content if json stream stopped with padding content, like `[1 , 1`
+func (lexer *Lexer) flushPaddingContent() {
+	if lexer.havePaddingContent() {
+		lexer.appendPaddingContentToJSONContent()
+		lexer.cleanPaddingContent()
 	}
-	tokenSymbol := lexer.JSONSegment[0]
+}
 
-	// check if ignored token
-	if isIgnoreToken(tokenSymbol) {
-		lexer.skipJSONSegment(1)
-		return TOKEN_IGNORED, tokenSymbol
-	}
+// check if JSON stream stopped in a string, like `["a` or `{"a`, but not in escape sequence
+func (lexer *Lexer) streamStoppedInAString() bool {
+	return lexer.state == STREAM_STATE_STRING || lexer.state == STREAM_STATE_KEY
+}
 
-	// match token
-	switch tokenSymbol {
-	case TOKEN_LEFT_BRACKET_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_LEFT_BRACKET, tokenSymbol
-	case TOKEN_RIGHT_BRACKET_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_RIGHT_BRACKET, tokenSymbol
-	case TOKEN_LEFT_BRACE_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_LEFT_BRACE, tokenSymbol
-	case TOKEN_RIGHT_BRACE_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_RIGHT_BRACE, tokenSymbol
-	case TOKEN_COLON_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_COLON, tokenSymbol
-	case TOKEN_DOT_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_DOT, tokenSymbol
-	case TOKEN_COMMA_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_COMMA, tokenSymbol
-	case TOKEN_QUOTE_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_QUOTE, tokenSymbol
-	case TOKEN_ESCAPE_CHARACTER_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ESCAPE_CHARACTER, tokenSymbol
-	case TOKEN_SLASH_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_SLASH, tokenSymbol
-	case TOKEN_NEGATIVE_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_NEGATIVE, tokenSymbol
-	case TOKEN_ALPHABET_LOWERCASE_A_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ALPHABET_LOWERCASE_A, tokenSymbol
-	case TOKEN_ALPHABET_LOWERCASE_B_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ALPHABET_LOWERCASE_B, tokenSymbol
-	case TOKEN_ALPHABET_LOWERCASE_C_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ALPHABET_LOWERCASE_C, tokenSymbol
-	case TOKEN_ALPHABET_LOWERCASE_D_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ALPHABET_LOWERCASE_D, tokenSymbol
-	case TOKEN_ALPHABET_LOWERCASE_E_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ALPHABET_LOWERCASE_E, tokenSymbol
-	case TOKEN_ALPHABET_LOWERCASE_F_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ALPHABET_LOWERCASE_F, tokenSymbol
-	case TOKEN_ALPHABET_LOWERCASE_L_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ALPHABET_LOWERCASE_L, tokenSymbol
-	case TOKEN_ALPHABET_LOWERCASE_N_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ALPHABET_LOWERCASE_N, tokenSymbol
-	case TOKEN_ALPHABET_LOWERCASE_R_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ALPHABET_LOWERCASE_R, tokenSymbol
-	case TOKEN_ALPHABET_LOWERCASE_S_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ALPHABET_LOWERCASE_S, tokenSymbol
-	case TOKEN_ALPHABET_LOWERCASE_T_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ALPHABET_LOWERCASE_T, tokenSymbol
-	case TOKEN_ALPHABET_LOWERCASE_U_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ALPHABET_LOWERCASE_U, tokenSymbol
-	case TOKEN_ALPHABET_UPPERCASE_A_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ALPHABET_UPPERCASE_A, tokenSymbol
-	case TOKEN_ALPHABET_UPPERCASE_B_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ALPHABET_UPPERCASE_B, tokenSymbol
-	case TOKEN_ALPHABET_UPPERCASE_C_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ALPHABET_UPPERCASE_C, tokenSymbol
-	case TOKEN_ALPHABET_UPPERCASE_D_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ALPHABET_UPPERCASE_D, tokenSymbol
-	case TOKEN_ALPHABET_UPPERCASE_E_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ALPHABET_UPPERCASE_E, tokenSymbol
-	case TOKEN_ALPHABET_UPPERCASE_F_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_ALPHABET_UPPERCASE_F, tokenSymbol
-	case TOKEN_NUMBER_0_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_NUMBER_0, tokenSymbol
-	case TOKEN_NUMBER_1_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_NUMBER_1, tokenSymbol
-	case TOKEN_NUMBER_2_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_NUMBER_2, tokenSymbol
-	case TOKEN_NUMBER_3_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_NUMBER_3, tokenSymbol
-	case TOKEN_NUMBER_4_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_NUMBER_4, tokenSymbol
-	case TOKEN_NUMBER_5_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_NUMBER_5, tokenSymbol
-	case TOKEN_NUMBER_6_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_NUMBER_6, tokenSymbol
-	case TOKEN_NUMBER_7_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_NUMBER_7, tokenSymbol
-	case TOKEN_NUMBER_8_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_NUMBER_8, tokenSymbol
-	case TOKEN_NUMBER_9_SYMBOL:
-		lexer.skipJSONSegment(1)
-		return TOKEN_NUMBER_9, tokenSymbol
-	default:
-		lexer.skipJSONSegment(1)
-		return TOKEN_OTHERS, tokenSymbol
+// get state after a value, by the closer of innermost container in mirror stack
+func (lexer *Lexer) getValueEndState() int {
+	for i := len(lexer.MirrorTokenStack) - 1; i >= 0; i-- {
+		switch lexer.MirrorTokenStack[i] {
+		case TOKEN_RIGHT_BRACKET:
+			return STREAM_STATE_ARRAY_VALUE_END
+		case TOKEN_RIGHT_BRACE:
+			return STREAM_STATE_OBJECT_VALUE_END
+		}
 	}
+	return STREAM_STATE_VALUE
 }
 
-// append JSON string to current JSON stream content
-func (lexer *Lexer) AppendString(str string) error {
-	return lexer.appendString(str)
+// get string state of escape state or unicode escape state
+func getStringStateOfEscapeState(state int) int {
+	switch state {
+	case STREAM_STATE_KEY_ESCAPE, STREAM_STATE_KEY_UNICODE_ESCAPE:
+		return STREAM_STATE_KEY
+	}
+	return STREAM_STATE_STRING
 }
 
-// append JSON string to current JSON stream content
-// this method will traversal all token and generate mirror token for complete full JSON
-func (lexer *Lexer) appendString(str string) error {
-	lexer.JSONSegment = str
-	for {
-		token, tokenSymbol := lexer.matchToken()
-
-		switch token {
-		case TOKEN_EOF:
-			// nothing to do with TOKEN_EOF
-		case TOKEN_IGNORED:
-			if lexer.streamStoppedInAString() {
-				lexer.JSONContent.WriteByte(tokenSymbol)
-				continue
-			}
-			lexer.pushByteIntoPaddingContent(tokenSymbol)
-
-		case TOKEN_OTHERS:
-			// check if json stream stopped with padding content
-			if lexer.havePaddingContent() {
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
-			}
-
-			// write current token symbol to JSON content
-			lexer.JSONContent.WriteByte(tokenSymbol)
-
-		case TOKEN_LEFT_BRACKET:
+// write escaped character following the padding escape character `\` into JSON content
+func (lexer *Lexer) writeEscapedCharacter(tokenSymbol byte) {
+	// push padding escape character `\` into JSON content
+	lexer.appendPaddingContentToJSONContent()
+	lexer.cleanPaddingContent()
 
-			// check if json stream stopped with padding content
-			if lexer.havePaddingContent() {
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
-			}
-			lexer.JSONContent.WriteByte(tokenSymbol)
-			if lexer.streamStoppedInAString() {
-				continue
-			}
-			lexer.pushTokenStack(token)
-			if lexer.streamStoppedInAnObjectArrayValueStart() {
-				// pop `n`, `u`, `l`, `l` from mirror stack
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-			}
+	// write current token symbol to JSON content
+	lexer.JSONContent.WriteByte(tokenSymbol)
 
-			// push `]` into mirror stack
-			lexer.pushMirrorTokenStack(TOKEN_RIGHT_BRACKET)
+	// pop `\` from  stack
+	lexer.popTokenStack()
+	lexer.state = getStringStateOfEscapeState(lexer.state)
+}
 
-		case TOKEN_RIGHT_BRACKET:
-			if lexer.streamStoppedInAString() {
-				lexer.JSONContent.WriteByte(tokenSymbol)
-				continue
-			}
+// push hex digit of unicode escape into padding content, the escape is written into JSON content when it is full length
+func (lexer *Lexer) pushUnicodeEscapeHexIntoPaddingContent(tokenSymbol byte) {
+	lexer.pushByteIntoPaddingContent(tokenSymbol)
+	// check if unicode escape is full length
+	if len(lexer.paddingContent) == 6 {
+		lexer.appendPaddingContentToJSONContent()
+		lexer.cleanPaddingContent()
+		// pop `\`, `u` from stack
+		lexer.popTokenStack()
+		lexer.popTokenStack()
+		lexer.state = getStringStateOfEscapeState(lexer.state)
+	}
+}
 
-			// check if json stream stopped with padding content
-			if lexer.havePaddingContent() {
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
-			}
+// drop the escape sequence in padding content, which can't be completed, like `\x` or `\u0"`
+func (lexer *Lexer) dropEscape() {
+	lexer.cleanPaddingContent()
+	// pop `\`, or `\`, `u` from stack
+	if lexer.state == STREAM_STATE_KEY_UNICODE_ESCAPE || lexer.state == STREAM_STATE_STRING_UNICODE_ESCAPE {
+		lexer.popTokenStack()
+	}
+	lexer.popTokenStack()
+	lexer.state = getStringStateOfEscapeState(lexer.state)
+}
 
-			// write current token symbol to JSON content
-			lexer.JSONContent.WriteByte(tokenSymbol)
+// mark a value (string, number, literal, object, array) as finalized in JSON stream
+func (lexer *Lexer) finalizeValue() {
+	lexer.finalizedValues++
+}
 
-			// push `]` into stack
-			lexer.pushTokenStack(token)
-			// pop `]` from mirror stack
-			lexer.popMirrorTokenStack()
+// append JSON string to current JSON stream content
+func (lexer *Lexer) AppendString(str string) error {
+	if lexer.flush.err != nil {
+		return lexer.flush.err
+	}
+	streamLength := lexer.streamLength
+	err := lexer.appendString(str)
+	lexer.trimTokenStack()
+	lexer.updatePaddingContent()
+	lexer.sourceMap.update(lexer.JSONContent.String(), lexer.paddingContent, str[:lexer.streamLength-streamLength], streamLength)
+	if lexer.flush.writer != nil {
+		if errInFlush := lexer.flushJSONContent(); errInFlush != nil {
+			return errInFlush
+		}
+	}
+	lexer.formatJSONContent(lexer.JSONContent.String())
+	return err
+}
 
-		case TOKEN_LEFT_BRACE:
-			// check if json stream stopped with padding content
-			if lexer.havePaddingContent() {
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
-			}
+// copy padding content into PaddingContent, it is written only if changed, so appending JSON segments of the same shape does not allocate
+func (lexer *Lexer) updatePaddingContent() {
+	if lexer.PaddingContent.String() != string(lexer.paddingContent) {
+		lexer.PaddingContent.Reset()
+		lexer.PaddingContent.Write(lexer.paddingContent)
+	}
+}
 
-			// write current token symbol to JSON content
-			lexer.JSONContent.WriteByte(tokenSymbol)
+// reserve JSON content for the JSON segment of given length, and the stacks for a new lexer,
+// so appending a JSON segment grows them at most once
+func (lexer *Lexer) reserve(segmentLength int) {
+	lexer.JSONContent.Grow(segmentLength)
+	if cap(lexer.MirrorTokenStack) == 0 {
+		lexer.MirrorTokenStack = make([]int, 0, MIRROR_TOKEN_STACK_INITIAL_CAPACITY)
+	}
+	if cap(lexer.pathFrames) == 0 {
+		lexer.pathFrames = make([]pathFrame, 0, PATH_FRAMES_INITIAL_CAPACITY)
+	}
+}
 
-			if lexer.streamStoppedInAString() {
-				continue
+// append JSON string to current JSON stream content.
+// every byte moves the lexer by the transition of current state and byte class, see streamTransitionTable,
+// and the transition generates mirror tokens for complete full JSON
+func (lexer *Lexer) appendString(str string) error {
+	if lexer.validation.violation != nil {
+		return lexer.validation.violation
+	}
+	if lexer.strict.err != nil {
+		return lexer.strict.err
+	}
+	lexer.JSONSegment = str
+	lexer.reserve(len(str))
+	validationEnabled := lexer.validation.enabled
+	strictEnabled := lexer.strict.enabled
+	// without validation and grammar checking, the runs of string content, whitespace, digits and literals are handled at once
+	checked := validationEnabled || strictEnabled
+	streamLength := lexer.streamLength
+	for i := 0; i < len(str); i++ {
+		tokenSymbol := str[i]
+		if checked {
+			lexer.streamLength = streamLength + i
+			// validate the last token by schema
+			if validationEnabled {
+				if err := lexer.validateLastToken(); err != nil {
+					return err
+				}
 			}
-			lexer.pushTokenStack(token)
-
-			if lexer.streamStoppedInAnObjectObjectValueStart() {
-				// pop `n`, `u`, `l`, `l` from mirror stack
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
+			// check the byte by JSON grammar in strict mode, before it changes anything
+			if strictEnabled {
+				if err := lexer.checkGrammar(tokenSymbol); err != nil {
+					return err
+				}
 			}
+			lexer.streamLength++
+		}
 
-			// push `}` into mirror stack
-			lexer.pushMirrorTokenStack(TOKEN_RIGHT_BRACE)
-
-		case TOKEN_RIGHT_BRACE:
-			if lexer.streamStoppedInAString() {
-				lexer.JSONContent.WriteByte(tokenSymbol)
-				continue
+		byteClass := byteClassTable[tokenSymbol]
+		transition := streamTransitionTable[lexer.state][byteClass]
+		switch transition {
+		case TRANSITION_END_NUMBER:
+			// the byte after number is after value
+			lexer.endNumber()
+			lexer.finalizeValue()
+			lexer.state = lexer.getValueEndState()
+			transition = streamTransitionTable[lexer.state][byteClass]
+		case TRANSITION_DROP_UNICODE_ESCAPE:
+			// the byte after incomplete unicode escape is in string
+			lexer.dropEscape()
+			transition = streamTransitionTable[lexer.state][byteClass]
+		case TRANSITION_START_ESCAPE:
+			// the short escape sequence complete in JSON segment is string content
+			if !checked && i+1 < len(str) && isShortEscapeCharacter(str[i+1]) {
+				transition = TRANSITION_STRING_CONTENT
 			}
+		}
 
-			// check if json stream stopped with padding content
-			if lexer.havePaddingContent() {
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
+		switch transition {
+		case TRANSITION_OTHERS:
+			lexer.writeOthers(tokenSymbol)
+
+		case TRANSITION_PADDING:
+			paddingEnd := i + 1
+			for !checked && paddingEnd < len(str) && byteClassTable[str[paddingEnd]] == BYTE_CLASS_WHITESPACE {
+				paddingEnd++
+			}
+			lexer.paddingContent = append(lexer.paddingContent, str[i:paddingEnd]...)
+			i = paddingEnd - 1
+
+		case TRANSITION_STRING_CONTENT:
+			// in a string, the bytes until quote or escape character are string content, write them at once.
+			// the escape sequences except unicode escape are string content too if they are complete in JSON segment
+			stringContentEnd := i + 1
+			if tokenSymbol == TOKEN_ESCAPE_CHARACTER_SYMBOL {
+				stringContentEnd++
+			}
+			for !checked {
+				stringContentEnd = indexQuoteOrEscapeCharacter(str, stringContentEnd)
+				if stringContentEnd+1 >= len(str) || str[stringContentEnd] != TOKEN_ESCAPE_CHARACTER_SYMBOL || !isShortEscapeCharacter(str[stringContentEnd+1]) {
+					break
+				}
+				stringContentEnd += 2
 			}
-			lexer.JSONContent.WriteByte(tokenSymbol)
-
-			// push `}` into stack
-			lexer.pushTokenStack(token)
-			// pop `}` from mirror stack
-			lexer.popMirrorTokenStack()
-
-		case TOKEN_QUOTE:
-			// check if escape quote `\"`
-			if lexer.streamStoppedWithLeadingEscapeCharacter() {
-				// push padding escape character `\` into JSON content
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
-
-				// write current token symbol to JSON content
-				lexer.JSONContent.WriteByte(tokenSymbol)
+			lexer.JSONContent.WriteString(str[i:stringContentEnd])
+			i = stringContentEnd - 1
 
-				// pop `\` from  stack
-				lexer.popTokenStack()
-				continue
+		case TRANSITION_OPEN_OBJECT, TRANSITION_OPEN_ARRAY:
+			token := TOKEN_LEFT_BRACE
+			closer := TOKEN_RIGHT_BRACE
+			if transition == TRANSITION_OPEN_ARRAY {
+				token = TOKEN_LEFT_BRACKET
+				closer = TOKEN_RIGHT_BRACKET
 			}
-
 			// check if json stream stopped with padding content
-			if lexer.havePaddingContent() {
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
-			}
-
-			// write current token symbol to JSON content
+			lexer.flushPaddingContent()
 			lexer.JSONContent.WriteByte(tokenSymbol)
+			lexer.openPathFrame(transition == TRANSITION_OPEN_ARRAY, lexer.JSONContent.Len()-1, token)
 			lexer.pushTokenStack(token)
-			if lexer.streamStoppedInAnArray() {
-				// push `"` into mirror stack
-				lexer.pushMirrorTokenStack(TOKEN_QUOTE)
-
-			} else if lexer.streamStoppedInAnArrayStringValueEnd() {
-				// pop `"` from mirror stack
-				lexer.popMirrorTokenStack()
-
-			} else if lexer.streamStoppedInAnObjectKeyStart() {
-				// check if stopped in key of object's properity or value of object's properity
-				// push `"`, `:`, `n`, `u`, `l`, `l` into mirror stack
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_L)
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_L)
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_U)
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_N)
-				lexer.pushMirrorTokenStack(TOKEN_COLON)
-				lexer.pushMirrorTokenStack(TOKEN_QUOTE)
-
-			} else if lexer.streamStoppedInAnObjectKeyEnd() {
-				// check if stopped in key of object's properity or value of object's properity
-				// pop `"` from mirror stack
-				lexer.popMirrorTokenStack()
-
-			} else if lexer.streamStoppedInAnObjectStringValueStart() {
+			if lexer.state == STREAM_STATE_OBJECT_VALUE {
 				// pop `n`, `u`, `l`, `l` from mirror stack
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-				// push `"` into mirror stack
-				lexer.pushMirrorTokenStack(TOKEN_QUOTE)
-
-			} else if lexer.streamStoppedInAnObjectValueEnd() {
-				// pop `"` from mirror stack
-				lexer.popMirrorTokenStack()
-
-			} else {
-				return fmt.Errorf("invalid quote token in json stream")
+				lexer.popMirrorTokens(MIRROR_PLACEHOLDER_OBJECT_VALUE_LENGTH)
 			}
-		case TOKEN_COLON:
-
-			if lexer.streamStoppedInAString() {
-				lexer.JSONContent.WriteByte(tokenSymbol)
-				continue
+			// push `}` or `]` into mirror stack
+			lexer.pushMirrorTokenStack(closer)
+			lexer.state = STREAM_STATE_OBJECT_KEY
+			if transition == TRANSITION_OPEN_ARRAY {
+				lexer.state = STREAM_STATE_ARRAY_VALUE
 			}
 
+		case TRANSITION_CLOSE:
 			// check if json stream stopped with padding content
-			if lexer.havePaddingContent() {
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
-			}
-
-			// write current token symbol to JSON content
+			lexer.flushPaddingContent()
 			lexer.JSONContent.WriteByte(tokenSymbol)
-
-			lexer.pushTokenStack(token)
-
-			// pop `:` from mirror stack
-			lexer.popMirrorTokenStack()
-		case TOKEN_ALPHABET_LOWERCASE_A:
-
-			// as hex in unicode
-			if lexer.streamStoppedInAnStringUnicodeEscape() {
-				lexer.pushByteIntoPaddingContent(tokenSymbol)
-				// check if unicode escape is full length
-				if lexer.PaddingContent.Len() == 6 {
-					lexer.appendPaddingContentToJSONContent()
-					lexer.cleanPaddingContent()
-					// pop `\`, `u` from stack
-					lexer.popTokenStack()
-					lexer.popTokenStack()
-				}
-				continue
-			}
-
-			// write current token symbol to JSON content
-			lexer.JSONContent.WriteByte(tokenSymbol)
-
-			// in a string, just skip token
-			if lexer.streamStoppedInAString() {
-				continue
-			}
-
-			// check if `f` in token stack and `a`, `l`, `s`, `e in mirror stack
-			itIsPartOfTokenFalse := func() bool {
-				left := []int{
-					TOKEN_ALPHABET_LOWERCASE_F,
-				}
-				right := []int{
-					TOKEN_ALPHABET_LOWERCASE_E,
-					TOKEN_ALPHABET_LOWERCASE_S,
-					TOKEN_ALPHABET_LOWERCASE_L,
-					TOKEN_ALPHABET_LOWERCASE_A,
-				}
-				return matchStack(lexer.TokenStack, left) && matchStack(lexer.MirrorTokenStack, right)
-			}
-
-			if !itIsPartOfTokenFalse() {
-				continue
-			}
-
-			lexer.pushTokenStack(token)
+			// push `}` or `]` into stack, and pop it from mirror stack
+			lexer.pushTokenStack(int(byteTokenTable[tokenSymbol]))
 			lexer.popMirrorTokenStack()
-		case TOKEN_ALPHABET_LOWERCASE_B:
-
-			// as hex in unicode
-			if lexer.streamStoppedInAnStringUnicodeEscape() {
-				lexer.pushByteIntoPaddingContent(tokenSymbol)
-				// check if unicode escape is full length
-				if lexer.PaddingContent.Len() == 6 {
-					lexer.appendPaddingContentToJSONContent()
-					lexer.cleanPaddingContent()
-					// pop `\`, `u` from stack
-					lexer.popTokenStack()
-					lexer.popTokenStack()
-				}
-				continue
-			}
+			lexer.closePathFrame()
+			lexer.finalizeValue()
+			lexer.state = lexer.getValueEndState()
 
-			// \b escape `\`, `b`
-			if lexer.streamStoppedWithLeadingEscapeCharacter() {
-				// push padding escape character `\` into JSON content
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
-
-				// write current token symbol to JSON content
-				lexer.JSONContent.WriteByte(tokenSymbol)
-
-				// pop `\` from  stack
-				lexer.popTokenStack()
-				continue
-			}
-
-			// write current token symbol to JSON content
+		case TRANSITION_START_KEY:
+			// check if json stream stopped with padding content, like `{"a":1, "`
+			lexer.flushPaddingContent()
 			lexer.JSONContent.WriteByte(tokenSymbol)
-
-			// in a string, just skip token
-			if lexer.streamStoppedInAString() {
-				continue
-			}
-		case TOKEN_ALPHABET_LOWERCASE_E:
-
-			// as hex in unicode
-			if lexer.streamStoppedInAnStringUnicodeEscape() {
-				lexer.pushByteIntoPaddingContent(tokenSymbol)
-				// check if unicode escape is full length
-				if lexer.PaddingContent.Len() == 6 {
-					lexer.appendPaddingContentToJSONContent()
-					lexer.cleanPaddingContent()
-					// pop `\`, `u` from stack
-					lexer.popTokenStack()
-					lexer.popTokenStack()
-				}
-				continue
-			}
-
-			// check if in a number, as `e` (exponent) in scientific notation
-			if lexer.streamStoppedInANumberDecimalPartMiddle() {
-				lexer.pushByteIntoPaddingContent(tokenSymbol)
-				continue
-			}
-
-			// write current token symbol to JSON content
+			lexer.pushTokenStack(TOKEN_QUOTE)
+			lexer.startPathObjectKey(lexer.JSONContent.Len() - 1)
+			stringEnd := -1
+			if !checked {
+				stringEnd = indexCompleteStringEnd(str, i)
+			}
+			if stringEnd > 0 {
+				// the key without escape sequence complete in JSON segment is written at once
+				lexer.JSONContent.WriteString(str[i+1 : stringEnd+1])
+				lexer.pushTokenStack(TOKEN_QUOTE)
+				lexer.endPathObjectKey(lexer.JSONContent.Len() - 1)
+				// push `:`, `n`, `u`, `l`, `l` into mirror stack
+				lexer.pushMirrorTokens(MIRROR_PLACEHOLDER_OBJECT_KEY[:len(MIRROR_PLACEHOLDER_OBJECT_KEY)-1])
+				lexer.state = STREAM_STATE_COLON
+				i = stringEnd
+				break
+			}
+			// push `"`, `:`, `n`, `u`, `l`, `l` into mirror stack
+			lexer.pushMirrorTokens(MIRROR_PLACEHOLDER_OBJECT_KEY)
+			lexer.state = STREAM_STATE_KEY
+
+		case TRANSITION_END_KEY:
 			lexer.JSONContent.WriteByte(tokenSymbol)
-
-			// in a string, just skip token
-			if lexer.streamStoppedInAString() {
-				continue
-			}
-
-			// check if `f`, `a`, `l`, `s` in token stack and `e` in mirror stack
-			itIsPartOfTokenFalse := func() bool {
-				left := []int{
-					TOKEN_ALPHABET_LOWERCASE_F,
-					TOKEN_ALPHABET_LOWERCASE_A,
-					TOKEN_ALPHABET_LOWERCASE_L,
-					TOKEN_ALPHABET_LOWERCASE_S,
-				}
-
-				right := []int{
-					TOKEN_ALPHABET_LOWERCASE_E,
-				}
-				return matchStack(lexer.TokenStack, left) && matchStack(lexer.MirrorTokenStack, right)
-			}
-
-			// check if `t`, `r`, `u` in token stack and `e` in mirror stack
-			itIsPartOfTokenTrue := func() bool {
-				left := []int{
-					TOKEN_ALPHABET_LOWERCASE_T,
-					TOKEN_ALPHABET_LOWERCASE_R,
-					TOKEN_ALPHABET_LOWERCASE_U,
-				}
-				right := []int{
-					TOKEN_ALPHABET_LOWERCASE_E,
-				}
-				return matchStack(lexer.TokenStack, left) && matchStack(lexer.MirrorTokenStack, right)
-			}
-			if !itIsPartOfTokenFalse() && !itIsPartOfTokenTrue() {
-				continue
-			}
-			lexer.pushTokenStack(token)
+			lexer.pushTokenStack(TOKEN_QUOTE)
+			lexer.endPathObjectKey(lexer.JSONContent.Len() - 1)
+			// pop `"` from mirror stack
 			lexer.popMirrorTokenStack()
-		case TOKEN_ALPHABET_LOWERCASE_F:
-
-			// as hex in unicode
-			if lexer.streamStoppedInAnStringUnicodeEscape() {
-				lexer.pushByteIntoPaddingContent(tokenSymbol)
-				// check if unicode escape is full length
-				if lexer.PaddingContent.Len() == 6 {
-					lexer.appendPaddingContentToJSONContent()
-					lexer.cleanPaddingContent()
-					// pop `\`, `u` from stack
-					lexer.popTokenStack()
-					lexer.popTokenStack()
-				}
-				continue
-			}
+			lexer.state = STREAM_STATE_COLON
 
-			// \f escape `\`, `f`
-			if lexer.streamStoppedWithLeadingEscapeCharacter() {
-				// push padding escape character `\` into JSON content
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
-
-				// write current token symbol to JSON content
-				lexer.JSONContent.WriteByte(tokenSymbol)
-
-				// pop `\` from  stack
-				lexer.popTokenStack()
-				continue
-			}
-
-			// check if json stream stopped with padding content, like case `[true , f`
-			if lexer.havePaddingContent() {
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
-			}
-
-			// write current token symbol to JSON content
-			lexer.JSONContent.WriteByte(tokenSymbol)
-
-			// in a string, just skip token
-			if lexer.streamStoppedInAString() {
-				continue
-			}
-
-			// push `f` into stack
-			lexer.pushTokenStack(token)
-			if lexer.streamStoppedInAnArray() {
-				// in array
-				// push `a`, `l`, `s`, `e`
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_E)
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_S)
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_L)
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_A)
-			} else {
-				// in object
-				// pop `n`, `u`, `l`, `l`
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-				// push `a`, `l`, `s`, `e`
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_E)
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_S)
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_L)
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_A)
-			}
-		case TOKEN_ALPHABET_LOWERCASE_L:
-			// write current token symbol to JSON content
+		case TRANSITION_COLON:
+			// check if json stream stopped with padding content, like `{"a" :`
+			lexer.flushPaddingContent()
 			lexer.JSONContent.WriteByte(tokenSymbol)
-
-			// in a string, just skip token
-			if lexer.streamStoppedInAString() {
-				continue
-			}
-
-			// check if `f`, `a` in token stack and, `l`, `s`, `e` in mirror stack
-			itIsPartOfTokenFalse := func() bool {
-				left := []int{
-					TOKEN_ALPHABET_LOWERCASE_F,
-					TOKEN_ALPHABET_LOWERCASE_A,
-				}
-				right := []int{
-					TOKEN_ALPHABET_LOWERCASE_E,
-					TOKEN_ALPHABET_LOWERCASE_S,
-					TOKEN_ALPHABET_LOWERCASE_L,
-				}
-				return matchStack(lexer.TokenStack, left) && matchStack(lexer.MirrorTokenStack, right)
-			}
-
-			// check if `n`, `u` in token stack and `l`, `l` in mirror stack
-			itIsPartOfTokenNull1 := func() bool {
-				left := []int{
-					TOKEN_ALPHABET_LOWERCASE_N,
-					TOKEN_ALPHABET_LOWERCASE_U,
-				}
-				right := []int{
-					TOKEN_ALPHABET_LOWERCASE_L,
-					TOKEN_ALPHABET_LOWERCASE_L,
-				}
-				return matchStack(lexer.TokenStack, left) && matchStack(lexer.MirrorTokenStack, right)
-			}
-
-			// check if `n`, `u`, `l` in token stack and `l` in mirror stack
-			itIsPartOfTokenNull2 := func() bool {
-				left := []int{
-					TOKEN_ALPHABET_LOWERCASE_N,
-					TOKEN_ALPHABET_LOWERCASE_U,
-					TOKEN_ALPHABET_LOWERCASE_L,
-				}
-				right := []int{
-					TOKEN_ALPHABET_LOWERCASE_L,
-				}
-				return matchStack(lexer.TokenStack, left) && matchStack(lexer.MirrorTokenStack, right)
-			}
-			if !itIsPartOfTokenFalse() && !itIsPartOfTokenNull1() && !itIsPartOfTokenNull2() {
-				continue
-			}
-			lexer.pushTokenStack(token)
+			lexer.pushTokenStack(TOKEN_COLON)
+			// pop `:` from mirror stack
 			lexer.popMirrorTokenStack()
+			lexer.state = STREAM_STATE_OBJECT_VALUE
 
-		case TOKEN_ALPHABET_LOWERCASE_N:
-			// \n escape `\`, `n`
-			if lexer.streamStoppedWithLeadingEscapeCharacter() {
-				// push padding escape character `\` into JSON content
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
-
-				// write current token symbol to JSON content
-				lexer.JSONContent.WriteByte(tokenSymbol)
-
-				// pop `\` from  stack
-				lexer.popTokenStack()
-				continue
-			}
-
-			// check if json stream stopped with padding content, like case `[true , n`
-			if lexer.havePaddingContent() {
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
-			}
-
-			// write current token symbol to JSON content
-			lexer.JSONContent.WriteByte(tokenSymbol)
-
-			// in a string, just skip token
-			if lexer.streamStoppedInAString() {
-				continue
-			}
-
-			// push `n`
-			lexer.pushTokenStack(token)
-			if lexer.streamStoppedInAnArray() {
-				// in array, push `u`, `l`, `l`
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_L)
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_L)
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_U)
+		case TRANSITION_COMMA:
+			// keep the comma in stack but not write it into JSONContent, until next token arrival
+			lexer.pushByteIntoPaddingContent(tokenSymbol)
+			lexer.pushTokenStack(TOKEN_COMMA)
+			if lexer.state == STREAM_STATE_ARRAY_VALUE_END {
+				lexer.state = STREAM_STATE_ARRAY_VALUE
 			} else {
-				// in object, pop `n`
-				lexer.popMirrorTokenStack()
-			}
-
-		case TOKEN_ALPHABET_LOWERCASE_R:
-			// \r escape `\`, `r`
-			if lexer.streamStoppedWithLeadingEscapeCharacter() {
-				// push padding escape character `\` into JSON content
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
-
-				// write current token symbol to JSON content
-				lexer.JSONContent.WriteByte(tokenSymbol)
-
-				// pop `\` from  stack
-				lexer.popTokenStack()
-				continue
+				lexer.state = STREAM_STATE_OBJECT_KEY
 			}
 
-			// write current token symbol to JSON content
+		case TRANSITION_START_STRING:
+			// check if json stream stopped with padding content, like `[1, "`
+			lexer.flushPaddingContent()
 			lexer.JSONContent.WriteByte(tokenSymbol)
-
-			// in a string, just skip token
-			if lexer.streamStoppedInAString() {
-				continue
-			}
-
-			// check if `t` in token stack and `r`, `u`, `e in mirror stack
-			itIsPartOfTokenTrue := func() bool {
-				left := []int{
-					TOKEN_ALPHABET_LOWERCASE_T,
-				}
-				right := []int{
-					TOKEN_ALPHABET_LOWERCASE_E,
-					TOKEN_ALPHABET_LOWERCASE_U,
-					TOKEN_ALPHABET_LOWERCASE_R,
-				}
-				return matchStack(lexer.TokenStack, left) && matchStack(lexer.MirrorTokenStack, right)
-			}
-			if !itIsPartOfTokenTrue() {
-				continue
-			}
-			lexer.pushTokenStack(token)
-			lexer.popMirrorTokenStack()
-
-		case TOKEN_ALPHABET_LOWERCASE_S:
-			// write current token symbol to JSON content
+			lexer.pushTokenStack(TOKEN_QUOTE)
+			lexer.startPathValue(lexer.JSONContent.Len()-1, TOKEN_QUOTE)
+			if lexer.state == STREAM_STATE_OBJECT_VALUE {
+				// pop `n`, `u`, `l`, `l` from mirror stack
+				lexer.popMirrorTokens(MIRROR_PLACEHOLDER_OBJECT_VALUE_LENGTH)
+			}
+			stringEnd := -1
+			if !checked {
+				stringEnd = indexCompleteStringEnd(str, i)
+			}
+			if stringEnd > 0 {
+				// the string without escape sequence complete in JSON segment is written at once
+				lexer.JSONContent.WriteString(str[i+1 : stringEnd+1])
+				lexer.pushTokenStack(TOKEN_QUOTE)
+				lexer.finalizeValue()
+				lexer.state = lexer.getValueEndState()
+				i = stringEnd
+				break
+			}
+			// push `"` into mirror stack
+			lexer.pushMirrorTokenStack(TOKEN_QUOTE)
+			lexer.state = STREAM_STATE_STRING
+
+		case TRANSITION_END_STRING:
 			lexer.JSONContent.WriteByte(tokenSymbol)
-
-			// in a string, just skip token
-			if lexer.streamStoppedInAString() {
-				continue
-			}
-
-			// check if `f`, `a`, `l` in token stack and `s`, `e in mirror stack
-			itIsPartOfTokenFalse := func() bool {
-				left := []int{
-					TOKEN_ALPHABET_LOWERCASE_F,
-					TOKEN_ALPHABET_LOWERCASE_A,
-					TOKEN_ALPHABET_LOWERCASE_L,
-				}
-				right := []int{
-					TOKEN_ALPHABET_LOWERCASE_E,
-					TOKEN_ALPHABET_LOWERCASE_S,
-				}
-				return matchStack(lexer.TokenStack, left) && matchStack(lexer.MirrorTokenStack, right)
-			}
-			if !itIsPartOfTokenFalse() {
-				continue
-			}
-			lexer.pushTokenStack(token)
+			lexer.pushTokenStack(TOKEN_QUOTE)
+			// pop `"` from mirror stack
 			lexer.popMirrorTokenStack()
-		case TOKEN_ALPHABET_LOWERCASE_T:
-
-			// \t escape `\`, `t`
-			if lexer.streamStoppedWithLeadingEscapeCharacter() {
-				// push padding escape character `\` into JSON content
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
-
-				// write current token symbol to JSON content
-				lexer.JSONContent.WriteByte(tokenSymbol)
-
-				// pop `\` from  stack
-				lexer.popTokenStack()
-				continue
-			}
-
-			// check if json stream stopped with padding content, like case `[true , t`
-			if lexer.havePaddingContent() {
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
-			}
-
-			// write current token symbol to JSON content
-			lexer.JSONContent.WriteByte(tokenSymbol)
-
-			// in a string, just skip token
-			if lexer.streamStoppedInAString() {
-				continue
-			}
+			lexer.finalizeValue()
+			lexer.state = lexer.getValueEndState()
 
-			// push `t` to stack
-			lexer.pushTokenStack(token)
-			if lexer.streamStoppedInAnArray() {
-				// in array
-				// push `r`, `u`, `e`
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_E)
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_U)
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_R)
+		case TRANSITION_START_ESCAPE:
+			// just write escape character into stack and waitting other token trigger escape method.
+			lexer.pushTokenStack(TOKEN_ESCAPE_CHARACTER)
+			lexer.pushByteIntoPaddingContent(TOKEN_ESCAPE_CHARACTER_SYMBOL)
+			if lexer.state == STREAM_STATE_KEY {
+				lexer.state = STREAM_STATE_KEY_ESCAPE
 			} else {
-				// in object
-				// pop `n`, `u`, `l`, `l`
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-				// push `r`, `u`, `e`
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_E)
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_U)
-				lexer.pushMirrorTokenStack(TOKEN_ALPHABET_LOWERCASE_R)
+				lexer.state = STREAM_STATE_STRING_ESCAPE
 			}
-		case TOKEN_ALPHABET_LOWERCASE_U:
 
-			// unicode escape `\`, `u`
-			if lexer.streamStoppedWithLeadingEscapeCharacter() {
-				lexer.pushTokenStack(token)
-				lexer.PaddingContent.WriteByte(tokenSymbol)
-				continue
-			}
+		case TRANSITION_SHORT_ESCAPE:
+			lexer.writeEscapedCharacter(tokenSymbol)
 
-			// write current token symbol to JSON content
-			lexer.JSONContent.WriteByte(tokenSymbol)
-
-			// in a string, just skip token
-			if lexer.streamStoppedInAString() {
-				continue
-			}
-
-			// check if `t`, `r` in token stack and, `u`, `e` in mirror stack
-			itIsPartOfTokenTrue := func() bool {
-				left := []int{
-					TOKEN_ALPHABET_LOWERCASE_T,
-					TOKEN_ALPHABET_LOWERCASE_R,
-				}
-				right := []int{
-					TOKEN_ALPHABET_LOWERCASE_E,
-					TOKEN_ALPHABET_LOWERCASE_U,
-				}
-				return matchStack(lexer.TokenStack, left) && matchStack(lexer.MirrorTokenStack, right)
-			}
-
-			// check if `n` in token stack and `u`, `l`, `l` in mirror stack
-			itIsPartOfTokenNull := func() bool {
-				left := []int{
-					TOKEN_ALPHABET_LOWERCASE_N,
-				}
-				right := []int{
-					TOKEN_ALPHABET_LOWERCASE_L,
-					TOKEN_ALPHABET_LOWERCASE_L,
-					TOKEN_ALPHABET_LOWERCASE_U,
-				}
-				return matchStack(lexer.TokenStack, left) && matchStack(lexer.MirrorTokenStack, right)
-			}
-			if !itIsPartOfTokenTrue() && !itIsPartOfTokenNull() {
-				continue
-			}
-			lexer.pushTokenStack(token)
-			lexer.popMirrorTokenStack()
-		case TOKEN_ALPHABET_UPPERCASE_A:
-			fallthrough
-		case TOKEN_ALPHABET_UPPERCASE_B:
-			fallthrough
-		case TOKEN_ALPHABET_UPPERCASE_C:
-			fallthrough
-		case TOKEN_ALPHABET_UPPERCASE_D:
-			fallthrough
-		case TOKEN_ALPHABET_LOWERCASE_C:
-			fallthrough
-		case TOKEN_ALPHABET_LOWERCASE_D:
-			fallthrough
-		case TOKEN_ALPHABET_UPPERCASE_F:
-
-			// as hex in unicode
-			if lexer.streamStoppedInAnStringUnicodeEscape() {
-				lexer.pushByteIntoPaddingContent(tokenSymbol)
-				// check if unicode escape is full length
-				if lexer.PaddingContent.Len() == 6 {
-					lexer.appendPaddingContentToJSONContent()
-					lexer.cleanPaddingContent()
-					// pop `\`, `u` from stack
-					lexer.popTokenStack()
-					lexer.popTokenStack()
-				}
-				continue
-			}
-
-			// write current token symbol to JSON content
-			lexer.JSONContent.WriteByte(tokenSymbol)
-
-			// in a string, just skip token
-			if lexer.streamStoppedInAString() {
-				continue
-			}
-		case TOKEN_ALPHABET_UPPERCASE_E:
-
-			// as hex in unicode
-			if lexer.streamStoppedInAnStringUnicodeEscape() {
-				lexer.pushByteIntoPaddingContent(tokenSymbol)
-				// check if unicode escape is full length
-				if lexer.PaddingContent.Len() == 6 {
-					lexer.appendPaddingContentToJSONContent()
-					lexer.cleanPaddingContent()
-					// pop `\`, `u` from stack
-					lexer.popTokenStack()
-					lexer.popTokenStack()
-				}
-				continue
-			}
-
-			// check if in a number, as `E` (exponent) in scientific notation
-			if lexer.streamStoppedInANumberDecimalPartMiddle() {
-				lexer.pushByteIntoPaddingContent(tokenSymbol)
-				continue
+		case TRANSITION_START_UNICODE_ESCAPE:
+			// unicode escape `\`, `u`
+			lexer.pushTokenStack(TOKEN_ALPHABET_LOWERCASE_U)
+			lexer.pushByteIntoPaddingContent(tokenSymbol)
+			if lexer.state == STREAM_STATE_KEY_ESCAPE {
+				lexer.state = STREAM_STATE_KEY_UNICODE_ESCAPE
+			} else {
+				lexer.state = STREAM_STATE_STRING_UNICODE_ESCAPE
 			}
 
-			// write current token symbol to JSON content
-			lexer.JSONContent.WriteByte(tokenSymbol)
+		case TRANSITION_UNICODE_ESCAPE_HEX:
+			lexer.pushUnicodeEscapeHexIntoPaddingContent(tokenSymbol)
 
-			// in a string, just skip token
-			if lexer.streamStoppedInAString() {
-				continue
-			}
-		case TOKEN_NUMBER_0:
-			fallthrough
-		case TOKEN_NUMBER_1:
-			fallthrough
-		case TOKEN_NUMBER_2:
-			fallthrough
-		case TOKEN_NUMBER_3:
-			fallthrough
-		case TOKEN_NUMBER_4:
-			fallthrough
-		case TOKEN_NUMBER_5:
-			fallthrough
-		case TOKEN_NUMBER_6:
-			fallthrough
-		case TOKEN_NUMBER_7:
-			fallthrough
-		case TOKEN_NUMBER_8:
-			fallthrough
-		case TOKEN_NUMBER_9:
-
-			if lexer.streamStoppedInAnStringUnicodeEscape() {
-				lexer.pushByteIntoPaddingContent(tokenSymbol)
-				// check if unicode escape is full length
-				if lexer.PaddingContent.Len() == 6 {
-					lexer.appendPaddingContentToJSONContent()
-					lexer.cleanPaddingContent()
-					// pop `\`, `u` from stack
-					lexer.popTokenStack()
-					lexer.popTokenStack()
-				}
-				continue
-			}
+		case TRANSITION_DROP_ESCAPE:
+			// the invalid escape sequence is dropped, like `\x`
+			lexer.dropEscape()
 
-			// check if json stream stopped with padding content, like `[1 , 1`
-			if lexer.havePaddingContent() {
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
+		case TRANSITION_START_LITERAL:
+			literalLength := 0
+			if !checked {
+				literalLength = lexer.appendCompleteLiteral(str[i:])
 			}
-
-			// in negative part of a number
-			if lexer.streamStoppedInANegativeNumberValueStart() {
-				lexer.pushNegativeIntoJSONContent()
-				// pop `0` from mirror stack
-				lexer.popMirrorTokenStack()
+			if literalLength > 0 {
+				i += literalLength - 1
+				break
 			}
+			lexer.startLiteral(tokenSymbol)
 
-			// write current token symbol to JSON content
-			lexer.JSONContent.WriteByte(tokenSymbol)
+		case TRANSITION_LITERAL:
+			lexer.continueLiteral(tokenSymbol)
 
-			// in a string or a number, just skip token
-			if lexer.streamStoppedInAString() || lexer.streamStoppedInANumber() {
-				continue
+		case TRANSITION_START_NUMBER:
+			lexer.startNumber(tokenSymbol)
+			if !checked {
+				i = lexer.continueDigitsInSegment(str, i+1) - 1
 			}
 
-			// in decimal part of a number
-			if lexer.streamStoppedInANumberDecimalPart() {
-				lexer.pushTokenStack(TOKEN_NUMBER)
-				// pop placeholder `0` in decimal part
-				lexer.popMirrorTokenStack()
-				continue
+		case TRANSITION_NUMBER:
+			// the byte can't continue the number is dropped, like the second `.` of `1.2.3` and `1` of `01`
+			lexer.continueNumber(tokenSymbol)
+			if !checked {
+				i = lexer.continueDigitsInSegment(str, i+1) - 1
 			}
 
-			// first number type token, push token into stack
-			lexer.pushTokenStack(TOKEN_NUMBER)
-
-			// check if we are in an object or an array
-			if lexer.streamStoppedInAnArray() {
-				continue
-			} else if lexer.streamStoppedInAnObjectNullValuePlaceholderStart() {
-				// pop `n`, `u`, `l`, `l`
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-			}
+		case TRANSITION_DROP_NUMBER:
+			// the number after value can't be completed into valid JSON without comma, like `2` of `[1 2`
 
-		case TOKEN_COMMA:
-			// in a string, just skip token
-			if lexer.streamStoppedInAString() {
-				lexer.JSONContent.WriteByte(tokenSymbol)
-				continue
-			}
-			// in a object or a array, keep the comma in stack but not write it into JSONContent, until next token arrival
-			// the comma must following with token: quote, null, true, false, number
-			lexer.pushByteIntoPaddingContent(tokenSymbol)
-			lexer.pushTokenStack(token)
-		case TOKEN_DOT:
-
-			// write current token symbol to JSON content
-			lexer.JSONContent.WriteByte(tokenSymbol)
-
-			// in a string, just skip token
-			if lexer.streamStoppedInAString() {
-				continue
-			}
-
-			// use 0 for decimal part place holder
-			lexer.pushTokenStack(token)
-			lexer.pushMirrorTokenStack(TOKEN_NUMBER_0)
-		case TOKEN_SLASH:
-
-			// escape character `\`, `/`
-			if lexer.streamStoppedWithLeadingEscapeCharacter() {
-				// push padding escape character `\` into JSON content
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
-
-				// write current token symbol to JSON content
-				lexer.JSONContent.WriteByte(tokenSymbol)
-
-				// pop `\` from  stack
-				lexer.popTokenStack()
-				continue
-			} else if lexer.streamStoppedInAString() {
-				// in a string, and the preceding token isn't an escape character, write current token symbol to JSON content
-				lexer.JSONContent.WriteByte(tokenSymbol)
-				continue
-			}
-
-		case TOKEN_ESCAPE_CHARACTER:
-
-			// double escape character `\`, `\`
-			if lexer.streamStoppedWithLeadingEscapeCharacter() {
-				// push padding escape character `\` into JSON content
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
-
-				// write current token symbol to JSON content
-				lexer.JSONContent.WriteByte(tokenSymbol)
-
-				// pop `\` from  stack
-				lexer.popTokenStack()
-				continue
-			}
-
-			// just write escape character into stack and waitting other token trigger escape method.
-			lexer.pushTokenStack(token)
-			lexer.pushByteIntoPaddingContent(TOKEN_ESCAPE_CHARACTER_SYMBOL)
-		case TOKEN_NEGATIVE:
-
-			// in a string, just skip token
-			if lexer.streamStoppedInAString() {
-				lexer.JSONContent.WriteByte(tokenSymbol)
-				continue
-			}
+		case TRANSITION_INVALID_QUOTE:
+			// the rest of JSON segment is dropped, but still counted in JSON stream length
+			lexer.streamLength = streamLength + len(str)
+			return fmt.Errorf("invalid quote token in json stream")
+		}
+	}
+	lexer.streamLength = streamLength + len(str)
+	// validate the last token by schema
+	if validationEnabled {
+		return lexer.validateLastToken()
+	}
+	return nil
+}
 
-			// check if json stream stopped with padding content, like `[1 , -`
-			if lexer.havePaddingContent() {
-				lexer.appendPaddingContentToJSONContent()
-				lexer.cleanPaddingContent()
-			}
+// write the byte out of JSON grammar into JSON content as it is, like `x` of `[1x`, the completed JSON is invalid then
+func (lexer *Lexer) writeOthers(tokenSymbol byte) {
+	// check if json stream stopped with padding content
+	lexer.flushPaddingContent()
+	lexer.JSONContent.WriteByte(tokenSymbol)
+}
 
-			// just write negative character into stack and waitting other token trigger it.
-			lexer.pushTokenStack(token)
-			if lexer.streamStoppedInAnObjectNegativeNumberValueStart() {
-				// pop `n`, `u`, `l`, `l` from mirror stack
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-				lexer.popMirrorTokenStack()
-			}
+// append the literal complete in JSON segment at once, returns length of the literal, 0 if it is not complete in JSON segment
+func (lexer *Lexer) appendCompleteLiteral(str string) int {
+	literal := getLiteralByFirstLetter(str[0])
+	if !strings.HasPrefix(str, literal) {
+		return 0
+	}
+	// check if json stream stopped with padding content, like case `[true , false`
+	lexer.flushPaddingContent()
+	lexer.JSONContent.WriteString(literal)
+	for i := 0; i < len(literal); i++ {
+		lexer.pushTokenStack(int(byteTokenTable[literal[i]]))
+	}
+	lexer.startPathValue(lexer.JSONContent.Len()-len(literal), int(byteTokenTable[literal[0]]))
+	if lexer.state == STREAM_STATE_OBJECT_VALUE {
+		// the placeholder `null` of properity value is popped
+		lexer.popMirrorTokens(MIRROR_PLACEHOLDER_OBJECT_VALUE_LENGTH)
+	}
+	lexer.finalizeValue()
+	lexer.state = lexer.getValueEndState()
+	return len(literal)
+}
 
-			// push `0` into mirror stack for placeholder
-			lexer.pushMirrorTokenStack(TOKEN_NUMBER_0)
-		default:
-			return fmt.Errorf("unexpected token: `%d`, token symbol: `%c`", token, tokenSymbol)
+// start literal `true`, `false` or `null` by the first letter in value state
+func (lexer *Lexer) startLiteral(tokenSymbol byte) {
+	// check if json stream stopped with padding content, like case `[true , f`
+	lexer.flushPaddingContent()
+	lexer.JSONContent.WriteByte(tokenSymbol)
+	token := int(byteTokenTable[tokenSymbol])
+	lexer.pushTokenStack(token)
+	lexer.startPathValue(lexer.JSONContent.Len()-1, token)
+	inObject := lexer.state == STREAM_STATE_OBJECT_VALUE
+	lexer.state = STREAM_STATE_LITERAL
+	if tokenSymbol == TOKEN_ALPHABET_LOWERCASE_N_SYMBOL {
+		if inObject {
+			// the placeholder `null` of properity value is the literal, pop `n`
+			lexer.popMirrorTokenStack()
+			return
 		}
+		// push `u`, `l`, `l`
+		lexer.pushMirrorTokens(MIRROR_PLACEHOLDER_NULL)
+		return
+	}
+	if inObject {
+		// pop `n`, `u`, `l`, `l`
+		lexer.popMirrorTokens(MIRROR_PLACEHOLDER_OBJECT_VALUE_LENGTH)
+	}
+	if tokenSymbol == TOKEN_ALPHABET_LOWERCASE_T_SYMBOL {
+		// push `r`, `u`, `e`
+		lexer.pushMirrorTokens(MIRROR_PLACEHOLDER_TRUE)
+		return
+	}
+	// push `a`, `l`, `s`, `e`
+	lexer.pushMirrorTokens(MIRROR_PLACEHOLDER_FALSE)
+}
 
-		// check if end
-		if token == TOKEN_EOF {
-			break
-		}
+// continue literal by next letter, the letter not on the mirror stack top is written as others, like `x` of `trx`
+func (lexer *Lexer) continueLiteral(tokenSymbol byte) {
+	token := int(byteTokenTable[tokenSymbol])
+	if token != lexer.getTopTokenOnMirrorStack() {
+		lexer.writeOthers(tokenSymbol)
+		return
 	}
-	return nil
+	lexer.JSONContent.WriteByte(tokenSymbol)
+	lexer.pushTokenStack(token)
+	lexer.popMirrorTokenStack()
+	// the literal ends if the rest of literal is not on the mirror stack top
+	switch lexer.getTopTokenOnMirrorStack() {
+	case TOKEN_ALPHABET_LOWERCASE_A, TOKEN_ALPHABET_LOWERCASE_E, TOKEN_ALPHABET_LOWERCASE_L, TOKEN_ALPHABET_LOWERCASE_R, TOKEN_ALPHABET_LOWERCASE_S, TOKEN_ALPHABET_LOWERCASE_U:
+		return
+	}
+	lexer.finalizeValue()
+	lexer.state = lexer.getValueEndState()
 }
 
 // complete the incomplete JSON string
@@ -1164,5 +694,5 @@ func (lexer *Lexer) CompleteJSON() string {
 
 // complete the incomplete JSON string by concat JSON content and mirror tokens
 func (lexer *Lexer) completeJSON() string {
-	return lexer.JSONContent.String() + lexer.dumpMirrorTokenStackToString()
+	return lexer.JSONContent.String() + lexer.completeJSONTail()
 }
@@ -0,0 +1,62 @@
+package streamingjsongo
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// iterator of complete elements of array value in JSON stream.
+// Next() returns the raw JSON of the next element as soon as it is complete, ErrNeedMoreData if it is not complete yet,
+// and io.EOF after the array closed and all elements returned.
+type ArrayIterator struct {
+	lexer   *Lexer
+	pointer string   // JSON pointer (RFC 6901) of the array value
+	tokens  []string // reference tokens of JSON pointer
+	node    *Node    // the array value node, nil if it did not arrive yet
+	next    int      // index of the next element
+}
+
+// get iterator of complete elements of the array value at JSON pointer (RFC 6901) path, like `/items`
+func (lexer *Lexer) ArrayIterator(pointer string) *ArrayIterator {
+	return &ArrayIterator{lexer: lexer, pointer: pointer, tokens: parseJSONPointer(pointer)}
+}
+
+// get raw JSON of the next complete element
+func (iterator *ArrayIterator) Next() (string, error) {
+	if iterator.node == nil {
+		node, err := iterator.lexer.lookupNode(iterator.pointer, iterator.tokens, NODE_KIND_ARRAY)
+		if node == nil {
+			if err == nil {
+				err = ErrNeedMoreData
+			}
+			return "", err
+		}
+		iterator.node = node
+	} else {
+		iterator.lexer.updateNodeTree()
+	}
+	if iterator.next < len(iterator.node.Children) && iterator.node.Children[iterator.next].Complete {
+		iterator.next++
+		return iterator.node.Children[iterator.next-1].Raw, nil
+	}
+	if iterator.node.Complete {
+		return "", io.EOF
+	}
+	return "", ErrNeedMoreData
+}
+
+// decode the next complete element into v by encoding/json
+func (iterator *ArrayIterator) Decode(v interface{}) error {
+	element, err := iterator.Next()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(element), v)
+}
+
+// decode the next complete element of array iterator into T by encoding/json
+func NextArrayElement[T any](iterator *ArrayIterator) (T, error) {
+	var element T
+	err := iterator.Decode(&element)
+	return element, err
+}
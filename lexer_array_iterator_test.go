@@ -0,0 +1,68 @@
+package streamingjsongo
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrayIterator(t *testing.T) {
+	document := `{"items": [{"id": 1, "tags": ["a", "]"]}, 2.5 , "x\"]", [[]], true], "next": []}`
+	expected := []string{`{"id": 1, "tags": ["a", "]"]}`, `2.5`, `"x\"]"`, `[[]]`, `true`}
+
+	// append byte by byte, each element is returned as soon as it is complete
+	lexer := NewLexer()
+	iterator := lexer.ArrayIterator("/items")
+	var elements []string
+	for i := 0; i < len(document); i++ {
+		lexer.AppendString(document[i : i+1])
+		element, err := iterator.Next()
+		if err == io.EOF {
+			break
+		}
+		if err == ErrNeedMoreData {
+			continue
+		}
+		assert.Nil(t, err)
+		elements = append(elements, element)
+		// the element is complete at its last byte, except number completed by the following byte
+		assert.True(t, strings.HasSuffix(document[:i+1], element) || strings.HasSuffix(document[:i], element), document[:i+1])
+	}
+	assert.Equal(t, expected, elements)
+	_, err := iterator.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestArrayIterator_decode(t *testing.T) {
+	type item struct {
+		ID   int      `json:"id"`
+		Tags []string `json:"tags"`
+	}
+	lexer := NewLexer()
+	iterator := lexer.ArrayIterator("")
+	lexer.AppendString(`[{"id": 1, "tags": ["a"]}, {"id": 2, "ta`)
+	element, err := NextArrayElement[item](iterator)
+	assert.Nil(t, err)
+	assert.Equal(t, item{ID: 1, Tags: []string{"a"}}, element)
+	_, err = NextArrayElement[item](iterator)
+	assert.Equal(t, ErrNeedMoreData, err)
+
+	lexer.AppendString(`gs": []}]`)
+	var second item
+	assert.Nil(t, iterator.Decode(&second))
+	assert.Equal(t, item{ID: 2, Tags: []string{}}, second)
+	assert.Equal(t, io.EOF, iterator.Decode(&second))
+}
+
+func TestArrayIterator_error(t *testing.T) {
+	lexer := NewLexer()
+	lexer.AppendString(`{"a": {}, "b": [`)
+	_, err := lexer.ArrayIterator("/a").Next()
+	assert.Equal(t, "value at `/a` is not array", err.Error())
+	_, err = lexer.ArrayIterator("/c").Next()
+	assert.Equal(t, ErrNeedMoreData, err)
+	_, err = lexer.ArrayIterator("/a/b").Next()
+	assert.Equal(t, "no value at `/a/b` in JSON stream", err.Error())
+}
@@ -0,0 +1,126 @@
+package streamingjsongo
+
+import (
+	"errors"
+	"io"
+)
+
+// the API needs the whole JSON content, which is flushed in bounded-memory mode
+var ErrContentFlushed = errors.New("JSON content is flushed in bounded-memory mode")
+
+// flushing of JSON content in bounded-memory mode
+type contentFlush struct {
+	writer  io.Writer // nil if not in bounded-memory mode
+	flushed int       // length of JSON content flushed
+	err     error     // the first error of writer, the lexer refuses more input after it
+}
+
+// new lexer in bounded-memory mode, the JSON content is flushed to w after each AppendString(),
+// only the current member (the key and scalar value in progress) of innermost container and the stack state are kept,
+// so the memory stays O(depth) rather than O(document) for long JSON stream.
+// the flushed content followed by CompleteJSON() is the completed JSON of the whole stream,
+// and the offsets of CompleteJSON() in SourceOffset() start from the content kept.
+// the value tree (Root(), Query(), ArrayIterator(), StringStream()), Tokenizer(), Decoder() and CompletionPatch()
+// need the whole JSON content, they return nil or ErrContentFlushed in bounded-memory mode.
+// StableLength(), Completion(), CompleteJSONIndent() and CompleteJSONCompact() only cover the content kept.
+func NewBoundedLexer(w io.Writer) *Lexer {
+	return &Lexer{flush: contentFlush{writer: w}}
+}
+
+// get length of JSON content flushed in bounded-memory mode
+func (lexer *Lexer) FlushedLength() int {
+	return lexer.flush.flushed
+}
+
+// flush JSON content to writer except the current member of innermost container,
+// which is still needed by completion and schema, like `"key": "val` of `{"a": 1, "key": "val`
+func (lexer *Lexer) flushJSONContent() error {
+	content := lexer.JSONContent.String()
+	keep := len(content)
+	if frame := lexer.getTopPathFrame(); frame != nil && frame.members > 0 {
+		// nothing of current member is needed if its value is a closed container.
+		// the value start before the member start is the stale one of last member, before the value of current member starts.
+		// the start of last closed frame and the value start are offsets in the same JSON content, so they are equal only if they are the same value.
+		valueIsClosedContainer := frame.valueStart >= frame.memberStart && lexer.lastClosedPathFrame.start == frame.valueStart
+		if !valueIsClosedContainer && frame.memberStart >= 0 {
+			keep = frame.memberStart
+		}
+	}
+	if keep == 0 {
+		return nil
+	}
+	// the formatted content of the content flushed is dropped with it
+	lexer.formatJSONContent(content[:keep])
+	if _, err := io.WriteString(lexer.flush.writer, content[:keep]); err != nil {
+		lexer.flush.err = err
+		return err
+	}
+	lexer.flush.flushed += keep
+	lexer.JSONContent.Reset()
+	lexer.JSONContent.WriteString(content[keep:])
+	lexer.shiftContentOffsets(keep)
+	lexer.indentedContent.drop(keep)
+	lexer.compactContent.drop(keep)
+	return nil
+}
+
+// shift the offsets in JSON content after the first n bytes flushed
+func (lexer *Lexer) shiftContentOffsets(n int) {
+	for i := range lexer.pathFrames {
+		lexer.pathFrames[i].shift(n)
+	}
+	lexer.lastClosedPathFrame.shift(n)
+
+	// the string measured by maxLength or enum is always kept, the flushed one is finished
+	validation := &lexer.validation
+	if validation.stringStart < n {
+		validation.stringStart = -1
+	} else {
+		validation.stringStart -= n
+		validation.stringScanned -= n
+	}
+	if validation.enumStart < n {
+		validation.enumStart = -1
+	} else {
+		validation.enumStart -= n
+		validation.enumScanned -= n
+	}
+
+	// the run at the start of JSON content kept replaces the flushed runs
+	sourceMap := &lexer.sourceMap
+	// the bytes out of JSON grammar written out of order may map the end of JSON content past the end of JSON stream
+	streamStart := sourceMap.streamOffset(n)
+	if streamStart > lexer.streamLength {
+		streamStart = lexer.streamLength
+	}
+	kept := 0
+	for _, run := range sourceMap.runs {
+		if run.content > n {
+			sourceMap.runs[kept] = sourceRun{content: run.content - n, stream: run.stream}
+			kept++
+		}
+	}
+	sourceMap.runs = append(sourceMap.runs[:kept], sourceRun{})
+	copy(sourceMap.runs[1:], sourceMap.runs[:kept])
+	sourceMap.runs[0] = sourceRun{content: 0, stream: streamStart}
+	sourceMap.mapped -= n
+}
+
+// shift the offsets of frame in JSON content after the first n bytes flushed, the finished key is kept in frame
+func (frame *pathFrame) shift(n int) {
+	frame.start -= n
+	frame.memberStart -= n
+	frame.valueStart -= n
+	frame.keyStart -= n
+	if frame.keyEnd >= 0 {
+		frame.keyEnd -= n
+		if frame.keyEnd < 0 {
+			frame.keyEnd = 0
+		}
+	}
+}
+
+// clone string, so the substring of JSON content does not keep the flushed content in memory
+func cloneString(s string) string {
+	return string([]byte(s))
+}
@@ -0,0 +1,122 @@
+package streamingjsongo
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBoundedLexer(t *testing.T) {
+	documents := []string{
+		`{"a你": [1, -2.5e+3, "x\"y", true, {"b": null}], "c": "😀", "d": [-], "e": {"f": [[], {}]}}`,
+		newDeepNestingJSONDocument(16),
+		newWideObjectJSONDocument(64),
+		newNumericArrayJSONDocument(128),
+	}
+	for _, document := range documents {
+		for _, chunkSize := range []int{1, 7, len(document)} {
+			lexer := NewLexer()
+			var flushed bytes.Buffer
+			bounded := NewBoundedLexer(&flushed)
+			for _, chunk := range splitIntoChunks(document, chunkSize) {
+				assert.Equal(t, lexer.AppendString(chunk), bounded.AppendString(chunk))
+				// the flushed content followed by completed JSON is the same
+				assert.Equal(t, lexer.CompleteJSON(), flushed.String()+bounded.CompleteJSON())
+				assert.Equal(t, flushed.Len(), bounded.FlushedLength())
+				assert.Equal(t, lexer.CurrentPath(), bounded.CurrentPath())
+				// only the current member is kept
+				assert.LessOrEqual(t, bounded.JSONContent.Len(), 24+chunkSize, bounded.JSONContent.String())
+				assert.LessOrEqual(t, len(bounded.TokenStack), 8)
+
+				completed := bounded.CompleteJSON()
+				for i := 0; i < bounded.JSONContent.Len(); i++ {
+					offset, ok := bounded.SourceOffset(i)
+					assert.True(t, ok)
+					assert.Equal(t, completed[i], document[offset])
+				}
+			}
+			assert.Equal(t, lexer.JSONContent.String(), flushed.String())
+		}
+	}
+}
+
+func TestNewBoundedLexer_currentScalar(t *testing.T) {
+	var flushed bytes.Buffer
+	lexer := NewBoundedLexer(&flushed)
+	lexer.AppendString(`{"a": [1, 2], "content": "hello`)
+	assert.Equal(t, `{"a": [1, 2], `, flushed.String())
+	assert.Equal(t, `"content": "hello"}`, lexer.CompleteJSON())
+	lexer.AppendString(` world", "b": tr`)
+	assert.Equal(t, `{"a": [1, 2], "content": "hello world", `, flushed.String())
+	assert.Equal(t, `"b": true}`, lexer.CompleteJSON())
+	lexer.AppendString(`ue}`)
+	assert.Equal(t, `{"a": [1, 2], "content": "hello world", "b": true}`, flushed.String())
+	assert.Equal(t, ``, lexer.CompleteJSON())
+}
+
+func TestNewBoundedLexer_unsupported(t *testing.T) {
+	var flushed bytes.Buffer
+	lexer := NewBoundedLexer(&flushed)
+	lexer.AppendString(`{"a": [1, 2], "b": "c`)
+	assert.Nil(t, lexer.Root())
+	_, err := lexer.Query("$.a")
+	assert.Equal(t, ErrContentFlushed, err)
+	_, err = lexer.ArrayIterator("/a").Next()
+	assert.Equal(t, ErrContentFlushed, err)
+	_, err = lexer.StringStream("/b").Read(make([]byte, 8))
+	assert.Equal(t, ErrContentFlushed, err)
+	_, err = lexer.Tokenizer().Next()
+	assert.Equal(t, ErrContentFlushed, err)
+	assert.Equal(t, ErrContentFlushed, lexer.Decoder().Decode(&struct{}{}))
+	_, err = lexer.Decoder().Token()
+	assert.Equal(t, ErrContentFlushed, err)
+	assert.True(t, lexer.Decoder().More())
+	_, err = lexer.CompletionPatch()
+	assert.Equal(t, ErrContentFlushed, err)
+	_, err = lexer.CompactCompletionPatch()
+	assert.Equal(t, ErrContentFlushed, err)
+	assert.Equal(t, `"b":"c"}`, lexer.CompleteJSONCompact())
+	// the stable length is relative to the content kept
+	assert.Equal(t, len(`"b": "c`), lexer.StableLength())
+	assert.Equal(t, len(`{"a": [1, 2], "b": "c`), lexer.FlushedLength()+lexer.StableLength())
+}
+
+// writer fails after given bytes written
+type failingWriter struct {
+	n int
+}
+
+func (writer *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > writer.n {
+		return 0, errors.New("disk full")
+	}
+	writer.n -= len(p)
+	return len(p), nil
+}
+
+func TestNewBoundedLexer_writeError(t *testing.T) {
+	lexer := NewBoundedLexer(&failingWriter{n: 8})
+	assert.Nil(t, lexer.AppendString(`{"a": 1, `))
+	assert.Equal(t, "disk full", lexer.AppendString(`"b": 2, `).Error())
+	// the error is sticky
+	assert.Equal(t, "disk full", lexer.AppendString(`"c": 3}`).Error())
+	// only `{` is flushed, the failed write is not counted
+	assert.Equal(t, 1, lexer.FlushedLength())
+}
+
+func TestNewBoundedLexer_resume(t *testing.T) {
+	document := `{"steps": [{"title": "plan"}, {"title": "write"}], "answer": "done"}`
+	var flushed bytes.Buffer
+	lexer := NewBoundedLexer(&flushed)
+	lexer.AppendString(document[:35])
+	data, err := lexer.MarshalBinary()
+	assert.Nil(t, err)
+	resumed := NewBoundedLexer(&flushed)
+	assert.Nil(t, resumed.UnmarshalBinary(data))
+	assert.Equal(t, lexer.CurrentPath(), resumed.CurrentPath())
+	assert.Nil(t, resumed.AppendString(document[35:]))
+	assert.Equal(t, document, flushed.String())
+	assert.Equal(t, len(document), resumed.FlushedLength())
+}
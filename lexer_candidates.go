@@ -0,0 +1,86 @@
+package streamingjsongo
+
+import (
+	"strings"
+)
+
+// JSON literals for prefix prediction
+var literalCandidates = []string{"true", "false", "null"}
+
+// get candidate completions of the partial string or literal on the cursor, strings are decoded.
+// the string value is constrained by enum (or const) of schema, the properity key by properity names if additional properties are not allowed,
+// nil if the cursor is not in a partial string or literal, or the partial value is not constrained.
+// like `{"status": "in_pro` gets `in_progress` for `{"enum": ["pending", "in_progress"]}`.
+func (lexer *Lexer) Candidates() []string {
+	candidates, _, _ := lexer.getCandidates()
+	return candidates
+}
+
+// append the rest of the only candidate into JSON stream, returns the appended JSON segment,
+// the string is closed with quote. nothing is appended if there are zero or multiple candidates.
+func (lexer *Lexer) AutoCompleteCandidate() (string, error) {
+	candidates, prefix, isString := lexer.getCandidates()
+	if len(candidates) != 1 {
+		return "", nil
+	}
+	var rest strings.Builder
+	if isString {
+		writeJSONStringSuffix(&rest, candidates[0], prefix)
+		rest.WriteByte(TOKEN_QUOTE_SYMBOL)
+	} else {
+		rest.WriteString(candidates[0][len(prefix):])
+	}
+	return rest.String(), lexer.AppendString(rest.String())
+}
+
+// get candidate completions and the partial value on the cursor, and if the partial value is a string
+func (lexer *Lexer) getCandidates() ([]string, string, bool) {
+	segment, ok := lexer.getMirrorTopSegment()
+	if !ok || len(segment) == 0 {
+		return nil, "", false
+	}
+	// the escape sequence in progress (or whitespace, comma) is not in JSON content yet
+	if lexer.havePaddingContent() {
+		return nil, "", false
+	}
+	content := lexer.JSONContent.String()
+	frame := lexer.getTopPathFrame()
+	switch {
+	case !frame.isArray && isObjectKeyPlaceholderSegment(segment):
+		schema := frame.schema.resolve()
+		if schema == nil || schema.additionalProperties == nil || !schema.additionalProperties.resolve().isFalse {
+			return nil, "", false
+		}
+		prefix := frame.lastKey(content)
+		return schema.getMissingPropertyNames(frame.keys, prefix, false), prefix, true
+	case isMirrorSegment(segment, TOKEN_QUOTE):
+		prefix := decodeJSONStringContent(content[frame.valueStart+1:])
+		return lexer.getCurrentValueSchema().getStringCandidates(prefix), prefix, true
+	case isLiteralPlaceholderSegment(segment):
+		prefix := content[frame.valueStart:]
+		var candidates []string
+		for _, literal := range literalCandidates {
+			if strings.HasPrefix(literal, prefix) {
+				candidates = append(candidates, literal)
+			}
+		}
+		return candidates, prefix, false
+	}
+	return nil, "", false
+}
+
+// check if mirror stack segment is the rest of literal in progress, like `ue` for `tr`
+func isLiteralPlaceholderSegment(segment []int) bool {
+	if len(segment) == 0 {
+		return false
+	}
+	for _, token := range segment {
+		switch token {
+		case TOKEN_ALPHABET_LOWERCASE_A, TOKEN_ALPHABET_LOWERCASE_E, TOKEN_ALPHABET_LOWERCASE_L,
+			TOKEN_ALPHABET_LOWERCASE_R, TOKEN_ALPHABET_LOWERCASE_S, TOKEN_ALPHABET_LOWERCASE_U:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package streamingjsongo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_CANDIDATES_SCHEMA = `{
+	"type": "object",
+	"properties": {
+		"status": {"enum": ["pending", "in_progress", "in_review", "done", 1]},
+		"kind": {"const": "a/b"},
+		"items": {"type": "array", "items": {"type": "object", "properties": {"state": {"enum": ["ok", "okay"]}}, "additionalProperties": false}},
+		"note": {"type": "string"}
+	}
+}`
+
+func TestCandidates(t *testing.T) {
+	streamingJSONCase := map[string][]string{
+		`{"status": "in_`:              {"in_progress", "in_review"},
+		`{"status": "in_p`:             {"in_progress"},
+		`{"status": "`:                 {"pending", "in_progress", "in_review", "done"},
+		`{"status": "x`:                nil,
+		`{"status": "in_progress"`:     nil,
+		`{"status": "\u0069n_`:         {"in_progress", "in_review"},
+		`{"status": "in\u00`:           nil,
+		`{"kind": "a\/`:                {"a/b"},
+		`{"note": "in_`:                nil,
+		`{"st`:                         nil,
+		`{"items": [{"st`:              {"state"},
+		`{"items": [{"state": "ok", "`: nil,
+		`{"items": [{"state": "o`:      {"ok", "okay"},
+		`{"items": [{"state": "ok`:     {"ok", "okay"},
+		`{"note": t`:                   {"true"},
+		`{"note": fal`:                 {"false"},
+		`{"items": [1, n`:              {"null"},
+		`{"note": `:                    nil,
+		`{"note": 1`:                   nil,
+	}
+	schema, err := NewSchema([]byte(TEST_CANDIDATES_SCHEMA))
+	assert.Nil(t, err)
+	for testCase, expect := range streamingJSONCase {
+		lexer := NewLexerWithSchema(schema)
+		assert.Nil(t, lexer.AppendString(testCase))
+		assert.Equal(t, expect, lexer.Candidates(), "unexpected candidates for `%s`", testCase)
+	}
+
+	// literals are predicted without schema
+	lexer := NewLexer()
+	assert.Nil(t, lexer.AppendString(`[tr`))
+	assert.Equal(t, []string{"true"}, lexer.Candidates())
+}
+
+func TestAutoCompleteCandidate(t *testing.T) {
+	streamingJSONCase := map[string]string{
+		`{"status": "in_p`:        `rogress"`,
+		`{"status": "in_`:         ``,
+		`{"kind": "`:              `a/b"`,
+		`{"items": [{"st`:         `ate"`,
+		`{"items": [{"state": "o`: ``,
+		`{"note": f`:              `alse`,
+		`{"note": "`:              ``,
+	}
+	schema, err := NewSchema([]byte(TEST_CANDIDATES_SCHEMA))
+	assert.Nil(t, err)
+	for testCase, expect := range streamingJSONCase {
+		lexer := NewLexerWithSchema(schema)
+		assert.Nil(t, lexer.AppendString(testCase))
+		completed := lexer.CompleteJSON()
+		appended, err := lexer.AutoCompleteCandidate()
+		assert.Nil(t, err)
+		assert.Equal(t, expect, appended, "unexpected auto-completion for `%s`", testCase)
+		assert.Equal(t, testCase+expect, lexer.JSONContent.String())
+		if expect != "" {
+			assert.Nil(t, lexer.Candidates())
+		}
+		// the auto-completion agrees with the schema guided completion
+		assert.Equal(t, completed, lexer.CompleteJSON())
+	}
+}
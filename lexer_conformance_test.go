@@ -0,0 +1,242 @@
+package streamingjsongo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const JSON_TEST_SUITE_DIR = "testdata/JSONTestSuite/test_parsing"
+
+// known deviations of lexer from JSONTestSuite, case name to reason.
+// the cases are checked as usual, and the test fails if a known deviation is fixed, so the list is always up to date.
+var knownConformanceDeviations = map[string]string{}
+
+// JSONTestSuite cases not vendored in testdata, case name to reason.
+// the large ones are generated by the same shape in TestJSONTestSuite_omitted.
+var omittedConformanceCases = map[string]string{
+	"n_structure_100000_opening_arrays.json": "100 KB of `[`, generated in TestJSONTestSuite_omitted",
+	"n_structure_open_array_object.json":     "250 KB of repeated `[{\"\":`, generated in TestJSONTestSuite_omitted",
+	"i_number_huge_exp.json":                 "the long exponent is not reproduced byte by byte, i_number_pos_double_huge_exp.json is the same kind",
+}
+
+// check accepted case (`y_`), returns the deviation found or empty string.
+// the document must be accepted in strict mode, completed into valid JSON at every cut point with stable part only growing,
+// and completed into itself at the end, appended byte by byte or at once.
+func checkAcceptedConformanceCase(document string) string {
+	if !json.Valid([]byte(document)) {
+		return "encoding/json rejects it"
+	}
+	lexer := NewStrictLexer()
+	stable := ""
+	for i := 0; i < len(document); i++ {
+		if err := lexer.AppendString(document[i : i+1]); err != nil {
+			return fmt.Sprintf("AppendString() failed: %s", err)
+		}
+		completed := lexer.CompleteJSON()
+		// nothing to complete before the root starts
+		if completed == "" && strings.TrimSpace(document[:i+1]) == "" {
+			continue
+		}
+		if !json.Valid([]byte(completed)) {
+			return fmt.Sprintf("invalid completed JSON `%s` at offset %d", completed, i)
+		}
+		if !strings.HasPrefix(completed, stable) {
+			return fmt.Sprintf("stable part `%s` changed in completed JSON `%s` at offset %d", stable, completed, i)
+		}
+		stable = lexer.Completion().Stable
+	}
+	if err := lexer.Finish(); err != nil {
+		return fmt.Sprintf("Finish() failed: %s", err)
+	}
+	expected := strings.TrimRight(document, " \t\n\r")
+	if completed := lexer.CompleteJSON(); completed != expected {
+		return fmt.Sprintf("completed JSON `%s` is not the document", completed)
+	}
+	lexer = NewLexer()
+	if err := lexer.AppendString(document); err != nil {
+		return fmt.Sprintf("AppendString() failed at once: %s", err)
+	}
+	if completed := lexer.CompleteJSON(); completed != expected {
+		return fmt.Sprintf("completed JSON `%s` is not the document when appended at once", completed)
+	}
+	return ""
+}
+
+// check rejected case (`n_`), returns the deviation found or empty string.
+// the document must be rejected in strict mode, by AppendString() or by Finish() if it is incomplete.
+func checkRejectedConformanceCase(document string) string {
+	if json.Valid([]byte(document)) {
+		return "encoding/json accepts it"
+	}
+	if strictLexerAccepts(document) {
+		return "strict mode accepts it"
+	}
+	return ""
+}
+
+// check if document is accepted by lexer in strict mode, appended byte by byte
+func strictLexerAccepts(document string) bool {
+	lexer := NewStrictLexer()
+	for i := 0; i < len(document); i++ {
+		if lexer.AppendString(document[i:i+1]) != nil {
+			return false
+		}
+	}
+	return lexer.Finish() == nil
+}
+
+func TestJSONTestSuite(t *testing.T) {
+	entries, err := os.ReadDir(JSON_TEST_SUITE_DIR)
+	assert.Nil(t, err)
+	var implementationDefined []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		document, err := os.ReadFile(filepath.Join(JSON_TEST_SUITE_DIR, name))
+		assert.Nil(t, err)
+		var deviation string
+		switch name[0] {
+		case 'y':
+			deviation = checkAcceptedConformanceCase(string(document))
+		case 'n':
+			deviation = checkRejectedConformanceCase(string(document))
+		case 'i':
+			// parsers are free to accept or reject, only reported
+			strictResult, encodingJSONResult := "rejected", "rejected"
+			if strictLexerAccepts(string(document)) {
+				strictResult = "accepted"
+			}
+			if json.Valid(document) {
+				encodingJSONResult = "accepted"
+			}
+			implementationDefined = append(implementationDefined, fmt.Sprintf("%s: %s in strict mode, %s by encoding/json", name, strictResult, encodingJSONResult))
+			continue
+		default:
+			continue
+		}
+		reason, isKnown := knownConformanceDeviations[name]
+		switch {
+		case deviation != "" && !isKnown:
+			t.Errorf("%s: unexpected deviation: %s", name, deviation)
+		case deviation == "" && isKnown:
+			t.Errorf("%s: known deviation (%s) is fixed, please remove it from knownConformanceDeviations", name, reason)
+		}
+	}
+
+	// report of known deviations, omitted and implementation defined cases, shown with `go test -v -run TestJSONTestSuite`
+	names := make([]string, 0, len(knownConformanceDeviations))
+	for name := range knownConformanceDeviations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t.Logf("known deviation %s: %s", name, knownConformanceDeviations[name])
+	}
+	names = names[:0]
+	for name := range omittedConformanceCases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t.Logf("omitted %s: %s", name, omittedConformanceCases[name])
+	}
+	for _, result := range implementationDefined {
+		t.Logf("implementation defined %s", result)
+	}
+}
+
+func TestJSONTestSuite_omitted(t *testing.T) {
+	generated := map[string]string{
+		"n_structure_100000_opening_arrays.json": strings.Repeat(`[`, 100000),
+		"n_structure_open_array_object.json":     strings.Repeat(`[{"":`, 50000) + "\n",
+	}
+	for name, document := range generated {
+		assert.Contains(t, omittedConformanceCases, name)
+		assert.Equal(t, "", checkRejectedConformanceCase(document), name)
+	}
+}
+
+func TestNewStrictLexer_syntaxError(t *testing.T) {
+	streamingJSONCase := map[string]SyntaxError{
+		`[1,]`:                   {Offset: 3, Message: "invalid character \"]\" looking for beginning of value"},
+		`{"a" 1}`:                {Offset: 5, Message: "invalid character \"1\" after properity key"},
+		`{"a":01}`:               {Offset: 6, Message: "invalid digit after leading zero of number"},
+		"[\"a\tb\"]":             {Offset: 3, Message: "invalid control character \"\\t\" in string"},
+		`["\x"]`:                 {Offset: 3, Message: "invalid character \"x\" in string escape"},
+		`[1.e1]`:                 {Offset: 3, Message: "invalid character \"e\" after decimal point of number"},
+		`[-01]`:                  {Offset: 3, Message: "invalid digit after leading zero of number"},
+		`[1.2.3]`:                {Offset: 4, Message: "invalid character \".\" after value"},
+		`[--1]`:                  {Offset: 2, Message: "invalid character \"-\" after negative sign"},
+		`[1e+e]`:                 {Offset: 4, Message: "invalid character \"e\" in exponent of number"},
+		`[tru ]`:                 {Offset: 4, Message: "invalid character \" \" in literal"},
+		`{"a":[}`:                {Offset: 6, Message: "invalid character \"}\" looking for beginning of value"},
+		`{"a":[1}`:               {Offset: 7, Message: "mismatched closer of ["},
+		`{"a":1} {}`:             {Offset: 8, Message: "invalid character \"{\" after root value"},
+		`{"a":1}` + "x":          {Offset: 7, Message: "invalid character \"x\" after root value"},
+		`"a" "b"`:                {Offset: 4, Message: "invalid character \"\\\"\" after root value"},
+		"[\"\xe5\"]":             {Offset: 3, Message: "invalid UTF-8 byte \"\\\"\" in string"},
+		"[\"\x80\"]":             {Offset: 2, Message: "invalid UTF-8 byte \"\\x80\" in string"},
+		"[\"\xc0\xaf\"]":         {Offset: 2, Message: "invalid UTF-8 byte \"\\xc0\" in string"},
+		"[\"\xe0\x80\xaf\"]":     {Offset: 3, Message: "invalid UTF-8 byte \"\\x80\" in string"},
+		"[\"\xed\xa0\x80\"]":     {Offset: 3, Message: "invalid UTF-8 byte \"\\xa0\" in string"},
+		"[\"\xf4\x90\x80\x80\"]": {Offset: 3, Message: "invalid UTF-8 byte \"\\x90\" in string"},
+	}
+	for testCase, expect := range streamingJSONCase {
+		lexer := NewStrictLexer()
+		var err error
+		for i := 0; i < len(testCase) && err == nil; i++ {
+			err = lexer.AppendString(testCase[i : i+1])
+		}
+		syntaxError, ok := err.(*SyntaxError)
+		if !assert.True(t, ok, testCase) {
+			continue
+		}
+		assert.Equal(t, expect, *syntaxError, testCase)
+		// the lexer refuses more input after syntax error
+		assert.Equal(t, err, lexer.AppendString(`]`), testCase)
+		assert.Equal(t, err, lexer.Finish(), testCase)
+	}
+}
+
+func TestNewStrictLexer_finish(t *testing.T) {
+	lexer := NewStrictLexer()
+	assert.Nil(t, lexer.AppendString(`{"a":[1, 2.5e-1, "\u00e9", true, null]`))
+	assert.Equal(t, `{"a":[1, 2.5e-1, "\u00e9", true, null]}`, lexer.CompleteJSON())
+	assert.Equal(t, &SyntaxError{Offset: 38, Message: "unexpected end of JSON stream"}, lexer.Finish())
+
+	lexer = NewStrictLexer()
+	assert.Nil(t, lexer.AppendString("{\"a\":[1, 2.5e-1, \"\\u00e9\", true, null]}\n"))
+	assert.Nil(t, lexer.Finish())
+
+	// UTF-8 character split into chunks
+	lexer = NewStrictLexer()
+	for _, c := range []byte(`{"é😀":"€"}`) {
+		assert.Nil(t, lexer.AppendString(string([]byte{c})))
+	}
+	assert.Nil(t, lexer.Finish())
+
+	// string as root value
+	lexer = NewStrictLexer()
+	assert.Nil(t, lexer.AppendString(`"a\"b`))
+	assert.Equal(t, `"a\"b"`, lexer.CompleteJSON())
+	assert.Equal(t, &SyntaxError{Offset: 5, Message: "unexpected end of JSON stream"}, lexer.Finish())
+
+	lexer = NewStrictLexer()
+	assert.Nil(t, lexer.AppendString(`"a\"b" `))
+	assert.Equal(t, `"a\"b"`, lexer.CompleteJSON())
+	assert.Nil(t, lexer.Finish())
+
+	// Finish() is no-op without strict mode
+	lexer = NewLexer()
+	assert.Nil(t, lexer.AppendString(`{"a":[1`))
+	assert.Nil(t, lexer.Finish())
+}
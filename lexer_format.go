@@ -0,0 +1,162 @@
+package streamingjsongo
+
+import (
+	"strings"
+)
+
+// state of formatting JSON, the whitespace out of string is dropped, and indented like json.Indent() if indented
+type jsonFormatter struct {
+	indented      bool
+	prefix        string
+	indent        string
+	depth         int  // depth of containers
+	inString      bool // in string, the bytes are written as is
+	escaped       bool // after escape character in string
+	openerPending bool // container opened and the new line not written yet, so the empty container stays in one line like `{}`
+}
+
+// JSON content formatted so far, the formatting is started by the first call of CompleteJSONIndent() or CompleteJSONCompact(),
+// then the JSON content is formatted by AppendString() as it is appended
+type formattedJSONContent struct {
+	started   bool
+	formatter jsonFormatter
+	content   strings.Builder
+	formatted int // length of JSON content formatted
+}
+
+// complete the incomplete JSON string, and indent it like json.Indent().
+// the JSON content is formatted during AppendString() after the first call, the call only formats the completed tail,
+// it starts over if prefix or indent changed.
+// in bounded-memory mode only the content kept is covered, the content flushed is formatted and dropped with it.
+func (lexer *Lexer) CompleteJSONIndent(prefix string, indent string) string {
+	return lexer.completeFormattedJSON(&lexer.indentedContent, jsonFormatter{indented: true, prefix: prefix, indent: indent})
+}
+
+// complete the incomplete JSON string, and drop the whitespace out of string like json.Compact().
+// the JSON content is formatted during AppendString() after the first call, the call only formats the completed tail.
+// in bounded-memory mode only the content kept is covered, the content flushed is formatted and dropped with it.
+func (lexer *Lexer) CompleteJSONCompact() string {
+	return lexer.completeFormattedJSON(&lexer.compactContent, jsonFormatter{})
+}
+
+// complete the incomplete JSON string formatted by given formatter, the formatting starts over if formatter options changed
+func (lexer *Lexer) completeFormattedJSON(formatted *formattedJSONContent, formatter jsonFormatter) string {
+	if !formatted.started || formatted.formatter.indented != formatter.indented || formatted.formatter.prefix != formatter.prefix || formatted.formatter.indent != formatter.indent {
+		lexer.startFormatting(formatted, formatter)
+	}
+
+	// format the tail by copy of formatter, the tail changes with JSON stream
+	tailFormatter := formatted.formatter
+	var completed strings.Builder
+	completed.WriteString(formatted.content.String())
+	tailFormatter.write(&completed, lexer.completeJSONTail())
+	return completed.String()
+}
+
+// start formatting JSON content by given formatter.
+// the content kept in bounded-memory mode is the current member of innermost container,
+// so the formatter starts at the depth of it, and the new line of opener is pending if it is the first member.
+func (lexer *Lexer) startFormatting(formatted *formattedJSONContent, formatter jsonFormatter) {
+	if lexer.flush.flushed > 0 {
+		formatter.depth = len(lexer.pathFrames)
+		if frame := lexer.getTopPathFrame(); frame != nil {
+			formatter.openerPending = formatter.indented && (frame.members == 0 || (frame.members == 1 && frame.memberStart == 0))
+		}
+	}
+	formatted.started = true
+	formatted.formatter = formatter
+	formatted.content.Reset()
+	formatted.formatted = 0
+	formatted.format(lexer.JSONContent.String())
+}
+
+// format JSON content appended since last formatting by started formatters
+func (lexer *Lexer) formatJSONContent(content string) {
+	lexer.indentedContent.format(content)
+	lexer.compactContent.format(content)
+}
+
+// format JSON content appended since last formatting
+func (formatted *formattedJSONContent) format(content string) {
+	if !formatted.started {
+		return
+	}
+	formatted.formatter.write(&formatted.content, content[formatted.formatted:])
+	formatted.formatted = len(content)
+}
+
+// drop the formatted content of the first n bytes of JSON content flushed, which are all formatted
+func (formatted *formattedJSONContent) drop(n int) {
+	if !formatted.started {
+		return
+	}
+	formatted.content.Reset()
+	formatted.formatted -= n
+}
+
+// write JSON segment into builder formatted
+func (formatter *jsonFormatter) write(builder *strings.Builder, segment string) {
+	for i := 0; i < len(segment); i++ {
+		c := segment[i]
+		if formatter.inString {
+			builder.WriteByte(c)
+			switch {
+			case formatter.escaped:
+				formatter.escaped = false
+			case c == TOKEN_ESCAPE_CHARACTER_SYMBOL:
+				formatter.escaped = true
+			case c == TOKEN_QUOTE_SYMBOL:
+				formatter.inString = false
+			}
+			continue
+		}
+		if isIgnoreToken(c) {
+			continue
+		}
+		isCloser := c == TOKEN_RIGHT_BRACE_SYMBOL || c == TOKEN_RIGHT_BRACKET_SYMBOL
+		if formatter.openerPending {
+			formatter.openerPending = false
+			if isCloser {
+				formatter.depth--
+				builder.WriteByte(c)
+				continue
+			}
+			formatter.writeNewLine(builder)
+		}
+		switch {
+		case c == TOKEN_LEFT_BRACE_SYMBOL || c == TOKEN_LEFT_BRACKET_SYMBOL:
+			builder.WriteByte(c)
+			formatter.depth++
+			formatter.openerPending = formatter.indented
+		case isCloser:
+			formatter.depth--
+			formatter.writeNewLine(builder)
+			builder.WriteByte(c)
+		case c == TOKEN_COMMA_SYMBOL:
+			builder.WriteByte(c)
+			formatter.writeNewLine(builder)
+		case c == TOKEN_COLON_SYMBOL:
+			builder.WriteByte(c)
+			if formatter.indented {
+				builder.WriteByte(' ')
+			}
+		case c == TOKEN_QUOTE_SYMBOL:
+			builder.WriteByte(c)
+			formatter.inString = true
+		default:
+			builder.WriteByte(c)
+		}
+	}
+}
+
+// write new line with prefix and indent of current depth if indented
+func (formatter *jsonFormatter) writeNewLine(builder *strings.Builder) {
+	if !formatter.indented {
+		return
+	}
+	builder.WriteByte('\n')
+	builder.WriteString(formatter.prefix)
+	for i := 0; i < formatter.depth; i++ {
+		builder.WriteString(formatter.indent)
+	}
+}
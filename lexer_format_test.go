@@ -0,0 +1,74 @@
+package streamingjsongo
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompleteJSONIndent(t *testing.T) {
+	lexer := NewLexer()
+	lexer.AppendString(`{"a": [1,   {}, [ ],{"b":"x , y"}], "c":tr`)
+	assert.Equal(t, "{\n>  \"a\": [\n>    1,\n>    {},\n>    [],\n>    {\n>      \"b\": \"x , y\"\n>    }\n>  ],\n>  \"c\": true\n>}", lexer.CompleteJSONIndent(">", "  "))
+	assert.Equal(t, `{"a":[1,{},[],{"b":"x , y"}],"c":true}`, lexer.CompleteJSONCompact())
+
+	// the opened container stays in one line until the first member arrives
+	lexer = NewLexer()
+	lexer.AppendString(`{"a": [`)
+	assert.Equal(t, "{\n\t\"a\": []\n}", lexer.CompleteJSONIndent("", "\t"))
+	lexer.AppendString(`"\"]`)
+	assert.Equal(t, "{\n\t\"a\": [\n\t\t\"\\\"]\"\n\t]\n}", lexer.CompleteJSONIndent("", "\t"))
+
+	// the formatted content is reset if options changed
+	assert.Equal(t, "{\n  \"a\": [\n    \"\\\"]\"\n  ]\n}", lexer.CompleteJSONIndent("", "  "))
+}
+
+func TestCompleteJSONIndent_matchEncodingJSON(t *testing.T) {
+	documents := []string{nestedJSONDocument, nestedJSONDocument2, escapedJSONDocument}
+	for testCase := range completeJSONBaseCases {
+		documents = append(documents, testCase)
+	}
+	for _, document := range documents {
+		lexer := NewLexer()
+		for i := 0; i < len(document); i++ {
+			lexer.AppendString(document[i : i+1])
+			completed := []byte(lexer.CompleteJSON())
+			if !json.Valid(completed) {
+				continue
+			}
+			var indented, compacted bytes.Buffer
+			json.Indent(&indented, completed, "", "  ")
+			json.Compact(&compacted, completed)
+			assert.Equal(t, indented.String(), lexer.CompleteJSONIndent("", "  "), document[:i+1])
+			assert.Equal(t, compacted.String(), lexer.CompleteJSONCompact(), document[:i+1])
+		}
+	}
+}
+
+func TestCompleteJSONIndent_boundedLexer(t *testing.T) {
+	documents := []string{nestedJSONDocument, nestedJSONDocument2, escapedJSONDocument}
+	for _, document := range documents {
+		// the formatting starts at the first segment, and after some content flushed
+		for _, start := range []int{1, len(document) / 2} {
+			lexer := NewLexer()
+			var flushed bytes.Buffer
+			boundedLexer := NewBoundedLexer(&flushed)
+			for i := 0; i < len(document); i++ {
+				lexer.AppendString(document[i : i+1])
+				boundedLexer.AppendString(document[i : i+1])
+				if i+1 < start {
+					continue
+				}
+				// the content kept is formatted at the depth of it
+				assert.True(t, strings.HasSuffix(lexer.CompleteJSONIndent("", "  "), boundedLexer.CompleteJSONIndent("", "  ")), document[:i+1])
+				assert.True(t, strings.HasSuffix(lexer.CompleteJSONCompact(), boundedLexer.CompleteJSONCompact()), document[:i+1])
+			}
+			// the formatted content is dropped with the content flushed
+			assert.LessOrEqual(t, boundedLexer.indentedContent.content.Len(), len(boundedLexer.CompleteJSONIndent("", "  ")))
+			assert.Equal(t, boundedLexer.JSONContent.Len(), boundedLexer.compactContent.formatted)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package streamingjsongo
+
+import (
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// split JSON stream into chunks at random points by given seed
+func splitIntoRandomChunks(s string, seed int64) []string {
+	random := rand.New(rand.NewSource(seed))
+	var chunks []string
+	for len(s) > 0 {
+		chunkSize := 1 + random.Intn(len(s))
+		// prefer small chunks, like tokens streamed from LLM
+		if random.Intn(4) > 0 && chunkSize > 8 {
+			chunkSize = 1 + random.Intn(8)
+		}
+		chunks = append(chunks, s[:chunkSize])
+		s = s[chunkSize:]
+	}
+	return chunks
+}
+
+// seed corpus of FuzzCompleteJSON, every kind of value as root value and in containers
+var fuzzCompleteJSONSeeds = []string{
+	`{}`,
+	`[]`,
+	`{"a": "b", "c": [1, -2.5e+3, true, false, null], "d": {"e": {}}}`,
+	`[{"a":[{"b":"c"},{"d":[]}]}, [[1], [2, [3]]]]`,
+	"{\n  \"key\": \"value\",\n\t\"array\": [ 1 , 2 ]\r\n}\n",
+	`{"escape": "\"\\\/\b\f\n\r\t", "unicode": "é😀", "raw": "这是一个字符串😀"}`,
+	`{"\"key\"": {"A": ["\\"]}}`,
+	`[0, -0, -0.0e-1, 0.5, 12.5E+3, -7e0, 1e-10, {"a":-0.05E+2}]`,
+	`"string"`,
+	` "a\"bé" `,
+	`-12.5e3`,
+	`0 `,
+	`true`,
+	` false `,
+	`null`,
+}
+
+func FuzzCompleteJSON(f *testing.F) {
+	for i, document := range fuzzCompleteJSONSeeds {
+		f.Add(document, int64(i))
+	}
+	f.Fuzz(func(t *testing.T, document string, seed int64) {
+		if !json.Valid([]byte(document)) {
+			t.Skip()
+		}
+		lexer := NewLexer()
+		stable := ""
+		appended := ""
+		for _, chunk := range splitIntoRandomChunks(document, seed) {
+			if err := lexer.AppendString(chunk); err != nil {
+				t.Fatalf("unexpected error after appending `%s`: %s", chunk, err)
+			}
+			appended += chunk
+			completed := lexer.CompleteJSON()
+			// nothing to complete before the root starts
+			if completed == "" && strings.TrimSpace(appended) == "" {
+				continue
+			}
+			if !json.Valid([]byte(completed)) {
+				t.Fatalf("invalid completed JSON `%s` after appending `%s`", completed, chunk)
+			}
+			// the stable part only grows, so checking the last one is enough
+			if !strings.HasPrefix(completed, stable) {
+				t.Fatalf("stable part `%s` changed in completed JSON `%s`", stable, completed)
+			}
+			stable = lexer.Completion().Stable
+			// every byte of JSON content maps back to the same byte of JSON stream, in order
+			previousOffset := -1
+			for i := 0; i < lexer.JSONContent.Len(); i++ {
+				offset, ok := lexer.SourceOffset(i)
+				if !ok || offset <= previousOffset || appended[offset] != completed[i] {
+					t.Fatalf("offset %d of completed JSON `%s` maps to offset %d of JSON stream `%s`", i, completed, offset, appended)
+				}
+				previousOffset = offset
+			}
+			// update value tree incrementally
+			lexer.Root()
+		}
+		if completed := lexer.CompleteJSON(); completed != strings.TrimRight(document, " \t\n\r") {
+			t.Fatalf("completed JSON `%s` is not equal to the JSON stream", completed)
+		}
+		root := lexer.Root()
+		if root.Raw != strings.TrimSpace(document) {
+			t.Fatalf("root node `%s` is not equal to the JSON stream", root.Raw)
+		}
+		// known limitation: the number as root value at the end of JSON stream may continue, so it is never complete
+		if root.Kind == NODE_KIND_NUMBER && !strings.ContainsAny(document[len(document)-1:], " \t\n\r") {
+			t.Logf("known limitation: number `%s` as root value is not complete without whitespace after it", root.Raw)
+			return
+		}
+		if !root.Complete {
+			t.Fatalf("root node `%s` is not complete", root.Raw)
+		}
+	})
+}
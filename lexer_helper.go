@@ -1,5 +1,9 @@
 package streamingjsongo
 
+import (
+	"math/bits"
+)
+
 func isIgnoreToken(c byte) bool {
 	switch c {
 	case '\t', '\n', '\v', '\f', '\r', ' ':
@@ -8,24 +12,164 @@ func isIgnoreToken(c byte) bool {
 	return false
 }
 
-func matchStack(stack []int, tokens []int) bool {
-	pointer := len(stack)
-	tokensLeft := len(tokens)
-	// fmt.Printf("current pointer: %+v, current tokensLeft: %+v\n", pointer, tokensLeft)
+// check if c is whitespace of JSON grammar (RFC 8259), `\v` and `\f` are not
+func isJSONWhitespace(c byte) bool {
+	switch c {
+	case '\t', '\n', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+// check if c is decimal digit
+func isDigit(c byte) bool {
+	return c >= TOKEN_NUMBER_0_SYMBOL && c <= TOKEN_NUMBER_9_SYMBOL
+}
+
+// check if c is hex digit of unicode escape
+func isHexDigit(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// check if c is the escaped character of short escape sequence in JSON string, like `n` in `\n`
+func isShortEscapeCharacter(c byte) bool {
+	switch c {
+	case TOKEN_QUOTE_SYMBOL, TOKEN_ESCAPE_CHARACTER_SYMBOL, TOKEN_SLASH_SYMBOL, 'b', 'f', 'n', 'r', 't':
+		return true
+	}
+	return false
+}
+
+// repeated bytes in a word for SWAR (SIMD within a register) scanning
+const (
+	SWAR_ONES             = 0x0101010101010101
+	SWAR_HIGH_BITS        = 0x8080808080808080
+	SWAR_QUOTE            = SWAR_ONES * TOKEN_QUOTE_SYMBOL
+	SWAR_ESCAPE_CHARACTER = SWAR_ONES * TOKEN_ESCAPE_CHARACTER_SYMBOL
+)
+
+// get index of the first quote `"` or escape character `\` in s from offset, len(s) if not found.
+// s is scanned 8 bytes at once, the bytes equal to quote or escape character are marked by the high bit of each byte
+func indexQuoteOrEscapeCharacter(s string, offset int) int {
+	for ; offset+8 <= len(s); offset += 8 {
+		word := uint64(s[offset]) | uint64(s[offset+1])<<8 | uint64(s[offset+2])<<16 | uint64(s[offset+3])<<24 |
+			uint64(s[offset+4])<<32 | uint64(s[offset+5])<<40 | uint64(s[offset+6])<<48 | uint64(s[offset+7])<<56
+		quotes := word ^ SWAR_QUOTE
+		escapeCharacters := word ^ SWAR_ESCAPE_CHARACTER
+		// the zero bytes have high bit set, the borrow only makes false positive above the first zero byte
+		found := ((quotes-SWAR_ONES)&^quotes | (escapeCharacters-SWAR_ONES)&^escapeCharacters) & SWAR_HIGH_BITS
+		if found != 0 {
+			return offset + bits.TrailingZeros64(found)/8
+		}
+	}
+	for ; offset < len(s); offset++ {
+		if s[offset] == TOKEN_QUOTE_SYMBOL || s[offset] == TOKEN_ESCAPE_CHARACTER_SYMBOL {
+			return offset
+		}
+	}
+	return offset
+}
+
+// get offset of the closing quote of string starting at the quote on given offset, -1 if the string has escape sequence or is not complete in s
+func indexCompleteStringEnd(s string, quoteOffset int) int {
+	stringEnd := indexQuoteOrEscapeCharacter(s, quoteOffset+1)
+	if stringEnd == len(s) || s[stringEnd] != TOKEN_QUOTE_SYMBOL {
+		return -1
+	}
+	return stringEnd
+}
 
-	for {
-		tokensLeft--
-		pointer--
-		if tokensLeft < 0 {
-			break
+// get literal `true`, `false` or `null` by its first letter
+func getLiteralByFirstLetter(c byte) string {
+	switch c {
+	case TOKEN_ALPHABET_LOWERCASE_T_SYMBOL:
+		return "true"
+	case TOKEN_ALPHABET_LOWERCASE_F_SYMBOL:
+		return "false"
+	}
+	return "null"
+}
+
+// skip JSON whitespace in s from offset, return offset of next non-whitespace character
+func skipJSONWhitespace(s string, offset int) int {
+	for offset < len(s) && isIgnoreToken(s[offset]) {
+		offset++
+	}
+	return offset
+}
+
+// scan JSON value in s from offset, return offset of the value end, or -1 if value not end in s
+func scanJSONValueEnd(s string, offset int) int {
+	if offset >= len(s) {
+		return -1
+	}
+	switch s[offset] {
+	case TOKEN_QUOTE_SYMBOL:
+		for i := offset + 1; i < len(s); i++ {
+			switch s[i] {
+			case TOKEN_ESCAPE_CHARACTER_SYMBOL:
+				i++
+			case TOKEN_QUOTE_SYMBOL:
+				return i + 1
+			}
+		}
+		return -1
+	case TOKEN_LEFT_BRACE_SYMBOL, TOKEN_LEFT_BRACKET_SYMBOL:
+		depth := 0
+		for i := offset; i < len(s); i++ {
+			switch s[i] {
+			case TOKEN_QUOTE_SYMBOL:
+				stringEnd := scanJSONValueEnd(s, i)
+				if stringEnd < 0 {
+					return -1
+				}
+				i = stringEnd - 1
+			case TOKEN_LEFT_BRACE_SYMBOL, TOKEN_LEFT_BRACKET_SYMBOL:
+				depth++
+			case TOKEN_RIGHT_BRACE_SYMBOL, TOKEN_RIGHT_BRACKET_SYMBOL:
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+		}
+		return -1
+	}
+	// number or literal
+	i := offset
+	for i < len(s) && !isIgnoreToken(s[i]) {
+		switch s[i] {
+		case TOKEN_COMMA_SYMBOL, TOKEN_RIGHT_BRACE_SYMBOL, TOKEN_RIGHT_BRACKET_SYMBOL:
+			return i
 		}
-		if pointer < 0 {
-			return false
+		i++
+	}
+	return i
+}
+
+// scan container member in s from offset, the offset is the key quote for object member or the value for array element.
+// returns the decoded key (for object member), the value range and offset of next member.
+func scanJSONMember(s string, offset int, isArray bool) (key string, valueStart int, valueEnd int, next int, ok bool) {
+	valueStart = offset
+	if !isArray {
+		keyEnd := scanJSONValueEnd(s, offset)
+		if keyEnd < 0 || s[offset] != TOKEN_QUOTE_SYMBOL {
+			return "", 0, 0, 0, false
 		}
-		// fmt.Printf("current stack: %+v, current token: %+v\n", stack[pointer], tokens[tokensLeft])
-		if stack[pointer] != tokens[tokensLeft] {
-			return false
+		key = decodeJSONStringContent(s[offset+1 : keyEnd-1])
+		valueStart = skipJSONWhitespace(s, keyEnd)
+		if valueStart >= len(s) || s[valueStart] != TOKEN_COLON_SYMBOL {
+			return "", 0, 0, 0, false
 		}
+		valueStart = skipJSONWhitespace(s, valueStart+1)
+	}
+	valueEnd = scanJSONValueEnd(s, valueStart)
+	if valueEnd < 0 {
+		return "", 0, 0, 0, false
+	}
+	next = skipJSONWhitespace(s, valueEnd)
+	if next < len(s) && s[next] == TOKEN_COMMA_SYMBOL {
+		next = skipJSONWhitespace(s, next+1)
 	}
-	return true
+	return key, valueStart, valueEnd, next, true
 }
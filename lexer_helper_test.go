@@ -1,62 +1,30 @@
 package streamingjsongo
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_matchStack0(t *testing.T) {
-	stack := []int{
-		TOKEN_LEFT_BRACE,
+func Test_indexQuoteOrEscapeCharacter(t *testing.T) {
+	cases := map[string]int{
+		``:                 0,
+		`abc`:              3,
+		`abc"`:             3,
+		`abcdefgh\"`:       8,
+		`abcdefghijklmnop`: 16,
+		`abcdefghijklmno"`: 15,
+		"这是一个字符串\\n":       21,
+		"\x01\x00\x80\xff\x7f\x21\x23\x5b\x5d\x5c": 9,
+		`0123456"\`:  7,
+		`01234567\"`: 8,
 	}
-	tokens := []int{
-		TOKEN_LEFT_BRACE,
+	for s, expected := range cases {
+		assert.Equal(t, expected, indexQuoteOrEscapeCharacter(s, 0), "unexpected index for `%s`", s)
+		if index := strings.IndexAny(s, `"\`); index >= 0 {
+			assert.Equal(t, index, expected, "unexpected case for `%s`", s)
+		}
 	}
-
-	matchResult := matchStack(stack, tokens)
-
-	assert.Equal(t, true, matchResult, "the tokens should be match")
-}
-
-func Test_matchStack1(t *testing.T) {
-	stack := []int{
-		TOKEN_RIGHT_BRACE,
-		TOKEN_ALPHABET_LOWERCASE_L,
-		TOKEN_ALPHABET_LOWERCASE_L,
-		TOKEN_ALPHABET_LOWERCASE_U,
-		TOKEN_ALPHABET_LOWERCASE_N,
-		TOKEN_COLON,
-	}
-	tokens := []int{
-		TOKEN_RIGHT_BRACE,
-		TOKEN_ALPHABET_LOWERCASE_L,
-		TOKEN_ALPHABET_LOWERCASE_L,
-		TOKEN_ALPHABET_LOWERCASE_U,
-		TOKEN_ALPHABET_LOWERCASE_N,
-		TOKEN_COLON,
-	}
-
-	matchResult := matchStack(stack, tokens)
-
-	assert.Equal(t, true, matchResult, "the tokens should be match")
-}
-
-func Test_matchStack2(t *testing.T) {
-	stack := []int{
-		TOKEN_LEFT_BRACE,
-		TOKEN_QUOTE,
-		TOKEN_QUOTE,
-		TOKEN_COLON,
-		TOKEN_ALPHABET_LOWERCASE_N,
-		TOKEN_ALPHABET_LOWERCASE_U,
-	}
-	tokens := []int{
-		TOKEN_ALPHABET_LOWERCASE_N,
-		TOKEN_ALPHABET_LOWERCASE_U,
-	}
-
-	matchResult := matchStack(stack, tokens)
-
-	assert.Equal(t, true, matchResult, "the tokens should be match")
+	assert.Equal(t, 12, indexQuoteOrEscapeCharacter(`"abc" "defgh" "`, 7))
 }
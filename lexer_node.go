@@ -0,0 +1,232 @@
+package streamingjsongo
+
+import (
+	"fmt"
+)
+
+// kinds of value node
+const (
+	NODE_KIND_OBJECT = iota
+	NODE_KIND_ARRAY
+	NODE_KIND_STRING
+	NODE_KIND_NUMBER
+	NODE_KIND_BOOL
+	NODE_KIND_NULL
+)
+
+// names of node kinds
+var nodeKindNames = []string{"object", "array", "string", "number", "boolean", "null"}
+
+// value node of JSON stream, the value may be partial while streaming
+type Node struct {
+	Kind     int     // NODE_KIND_*
+	Key      string  // decoded properity key if the node is properity value of object
+	Children []*Node // properity values of object or elements of array, in order
+	Raw      string  // raw JSON of the value appended so far, like `"hel` or `tr` if it is still streaming
+	Complete bool    // the value is complete, false if it is still streaming
+	start    int     // offset of the value in JSONContent
+}
+
+// value tree built from JSON content, updated incrementally
+type nodeTree struct {
+	root         *Node
+	containers   []*Node // the open containers on the path of JSON content end
+	scalar       *Node   // the scalar value in progress
+	scanned      int     // length of JSON content scanned
+	inString     bool
+	escaped      bool
+	inKey        bool   // in properity key
+	expectingKey bool   // the next string in object is properity key
+	keyStart     int    // offset of properity key content in JSONContent
+	key          string // decoded properity key for the next properity value
+}
+
+// get the root value node of JSON stream, nil if root value not started.
+// the tree is updated on each call, only the nodes touched by the JSON content appended since last call are updated,
+// so walking the tree after each chunk costs O(chunk) rather than re-parsing the completed JSON.
+func (lexer *Lexer) Root() *Node {
+	if lexer.flush.writer != nil {
+		return nil
+	}
+	lexer.updateNodeTree()
+	return lexer.nodeTree.root
+}
+
+// get the decoded content of string node, the partial content if it is still streaming,
+// or the raw JSON of the other nodes
+func (node *Node) Text() string {
+	if node.Kind != NODE_KIND_STRING {
+		return node.Raw
+	}
+	raw := node.Raw[1:]
+	if node.Complete {
+		raw = raw[:len(raw)-1]
+	}
+	return decodeJSONStringContent(raw)
+}
+
+// scan JSON content appended since last update into value tree
+func (lexer *Lexer) updateNodeTree() {
+	tree := &lexer.nodeTree
+	content := lexer.JSONContent.String()
+	for i := tree.scanned; i < len(content); i++ {
+		tree.scan(content, i)
+	}
+	tree.scanned = len(content)
+
+	// the number is complete if followed by whitespace or comma in padding content
+	if tree.scalar != nil && tree.scalar.Kind == NODE_KIND_NUMBER && lexer.havePaddingContent() {
+		if c := lexer.paddingContent[0]; isIgnoreToken(c) || c == TOKEN_COMMA_SYMBOL {
+			tree.completeScalar(content, len(content))
+		}
+	}
+
+	// the streaming values are raw JSON until content end
+	if tree.scalar != nil {
+		tree.scalar.Raw = content[tree.scalar.start:]
+	}
+	for _, container := range tree.containers {
+		container.Raw = content[container.start:]
+	}
+}
+
+// scan byte of JSON content at offset i
+func (tree *nodeTree) scan(content string, i int) {
+	c := content[i]
+	if tree.inString {
+		switch {
+		case tree.escaped:
+			tree.escaped = false
+		case c == TOKEN_ESCAPE_CHARACTER_SYMBOL:
+			tree.escaped = true
+		case c == TOKEN_QUOTE_SYMBOL && tree.inKey:
+			tree.inString = false
+			tree.inKey = false
+			tree.key = decodeJSONStringContent(content[tree.keyStart:i])
+		case c == TOKEN_QUOTE_SYMBOL:
+			tree.inString = false
+			tree.completeScalar(content, i+1)
+		}
+		return
+	}
+	switch c {
+	case TOKEN_LEFT_BRACE_SYMBOL, TOKEN_LEFT_BRACKET_SYMBOL:
+		tree.completeScalar(content, i)
+		kind := NODE_KIND_OBJECT
+		if c == TOKEN_LEFT_BRACKET_SYMBOL {
+			kind = NODE_KIND_ARRAY
+		}
+		node := tree.addNode(kind, i)
+		tree.containers = append(tree.containers, node)
+		tree.expectingKey = kind == NODE_KIND_OBJECT
+	case TOKEN_RIGHT_BRACE_SYMBOL, TOKEN_RIGHT_BRACKET_SYMBOL:
+		tree.completeScalar(content, i)
+		containersLen := len(tree.containers)
+		if containersLen == 0 {
+			return
+		}
+		container := tree.containers[containersLen-1]
+		container.Raw = content[container.start : i+1]
+		container.Complete = true
+		tree.containers = tree.containers[:containersLen-1]
+		tree.expectingKey = false
+	case TOKEN_COMMA_SYMBOL:
+		tree.completeScalar(content, i)
+		tree.expectingKey = tree.inObject()
+	case TOKEN_COLON_SYMBOL:
+	case TOKEN_QUOTE_SYMBOL:
+		tree.inString = true
+		if tree.expectingKey && tree.inObject() {
+			tree.inKey = true
+			tree.expectingKey = false
+			tree.keyStart = i + 1
+			return
+		}
+		tree.addNode(NODE_KIND_STRING, i)
+	default:
+		if isIgnoreToken(c) {
+			tree.completeScalar(content, i)
+			return
+		}
+		// the rest of number or literal
+		if tree.scalar != nil {
+			tree.completeLiteral(content, i)
+			return
+		}
+		switch c {
+		case TOKEN_ALPHABET_LOWERCASE_T_SYMBOL, TOKEN_ALPHABET_LOWERCASE_F_SYMBOL:
+			tree.addNode(NODE_KIND_BOOL, i)
+		case TOKEN_ALPHABET_LOWERCASE_N_SYMBOL:
+			tree.addNode(NODE_KIND_NULL, i)
+		default:
+			tree.addNode(NODE_KIND_NUMBER, i)
+		}
+		tree.completeLiteral(content, i)
+	}
+}
+
+// check if the innermost open container is object
+func (tree *nodeTree) inObject() bool {
+	containersLen := len(tree.containers)
+	return containersLen > 0 && tree.containers[containersLen-1].Kind == NODE_KIND_OBJECT
+}
+
+// add value node started at given offset into the innermost open container, or as root
+func (tree *nodeTree) addNode(kind int, offset int) *Node {
+	node := &Node{Kind: kind, start: offset}
+	containersLen := len(tree.containers)
+	if containersLen == 0 {
+		if tree.root == nil {
+			tree.root = node
+		}
+	} else {
+		parent := tree.containers[containersLen-1]
+		if parent.Kind == NODE_KIND_OBJECT {
+			node.Key = tree.key
+		}
+		parent.Children = append(parent.Children, node)
+	}
+	if kind != NODE_KIND_OBJECT && kind != NODE_KIND_ARRAY {
+		tree.scalar = node
+	}
+	return node
+}
+
+// complete the scalar value in progress, the value ends at given offset
+func (tree *nodeTree) completeScalar(content string, end int) {
+	if tree.scalar == nil {
+		return
+	}
+	tree.scalar.Raw = content[tree.scalar.start:end]
+	tree.scalar.Complete = true
+	tree.scalar = nil
+}
+
+// complete the literal in progress if the byte at offset i is the last byte of it
+func (tree *nodeTree) completeLiteral(content string, i int) {
+	if tree.scalar.Kind != NODE_KIND_BOOL && tree.scalar.Kind != NODE_KIND_NULL {
+		return
+	}
+	switch content[tree.scalar.start : i+1] {
+	case "true", "false", "null":
+		tree.completeScalar(content, i+1)
+	}
+}
+
+// look up value node of given kind at JSON pointer path, returns nil node and nil error if it did not arrive yet
+func (lexer *Lexer) lookupNode(pointer string, tokens []string, kind int) (*Node, error) {
+	if lexer.flush.writer != nil {
+		return nil, ErrContentFlushed
+	}
+	node, missing := findNodeByJSONPointer(lexer.Root(), tokens)
+	if missing {
+		return nil, fmt.Errorf("no value at `%s` in JSON stream", pointer)
+	}
+	if node == nil {
+		return nil, nil
+	}
+	if node.Kind != kind {
+		return nil, fmt.Errorf("value at `%s` is not %s", pointer, nodeKindNames[kind])
+	}
+	return node, nil
+}
@@ -0,0 +1,112 @@
+package streamingjsongo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoot(t *testing.T) {
+	lexer := NewLexer()
+	assert.Nil(t, lexer.Root())
+
+	lexer.AppendString(`{"a\nb": [1, "x\"y你", {"c": tr`)
+	root := lexer.Root()
+	assert.Equal(t, NODE_KIND_OBJECT, root.Kind)
+	assert.False(t, root.Complete)
+	assert.Equal(t, 1, len(root.Children))
+
+	array := root.Children[0]
+	assert.Equal(t, NODE_KIND_ARRAY, array.Kind)
+	assert.Equal(t, "a\nb", array.Key)
+	assert.False(t, array.Complete)
+	assert.Equal(t, 3, len(array.Children))
+	assert.Equal(t, Node{Kind: NODE_KIND_NUMBER, Raw: `1`, Complete: true, start: 10}, *array.Children[0])
+	assert.Equal(t, Node{Kind: NODE_KIND_STRING, Raw: `"x\"y你"`, Complete: true, start: 13}, *array.Children[1])
+	assert.Equal(t, "x\"y你", array.Children[1].Text())
+
+	literal := array.Children[2].Children[0]
+	assert.Equal(t, Node{Kind: NODE_KIND_BOOL, Key: "c", Raw: `tr`, start: 30}, *literal)
+
+	// only the nodes touched are updated, the others are kept as is
+	lexer.AppendString(`ue}, -1.5 , "partial`)
+	assert.Equal(t, root, lexer.Root())
+	assert.Equal(t, Node{Kind: NODE_KIND_BOOL, Key: "c", Raw: `true`, Complete: true, start: 30}, *literal)
+	assert.True(t, array.Children[2].Complete)
+	assert.Equal(t, Node{Kind: NODE_KIND_NUMBER, Raw: `-1.5`, Complete: true, start: 37}, *array.Children[3])
+	assert.Equal(t, Node{Kind: NODE_KIND_STRING, Raw: `"partial`, start: 44}, *array.Children[4])
+	assert.Equal(t, "partial", array.Children[4].Text())
+
+	// the tree is updated by Root()
+	lexer.AppendString(`"]}`)
+	assert.False(t, root.Complete)
+	assert.True(t, lexer.Root().Complete)
+	assert.Equal(t, lexer.JSONContent.String(), root.Raw)
+}
+
+func TestRoot_numberCompletedByPadding(t *testing.T) {
+	lexer := NewLexer()
+	lexer.AppendString(`[12`)
+	number := lexer.Root().Children[0]
+	assert.False(t, number.Complete)
+	lexer.AppendString(`,`)
+	lexer.Root()
+	assert.Equal(t, Node{Kind: NODE_KIND_NUMBER, Raw: `12`, Complete: true, start: 1}, *number)
+	lexer.AppendString(` 3]`)
+	assert.Equal(t, 2, len(lexer.Root().Children))
+	assert.Equal(t, `3`, lexer.Root().Children[1].Raw)
+}
+
+// check node tree invariants: the complete nodes are valid JSON, and the values of properity have keys
+func checkNodeTree(t *testing.T, node *Node, inObject bool, context string) {
+	if node.Complete {
+		assert.True(t, json.Valid([]byte(node.Raw)), context)
+	}
+	if !inObject {
+		assert.Equal(t, "", node.Key, context)
+	}
+	for _, child := range node.Children {
+		checkNodeTree(t, child, node.Kind == NODE_KIND_OBJECT, context)
+	}
+}
+
+func TestRoot_documents(t *testing.T) {
+	for _, document := range []string{nestedJSONDocument, nestedJSONDocument2, escapedJSONDocument} {
+		lexer := NewLexer()
+		for i := 0; i < len(document); i++ {
+			lexer.AppendString(document[i : i+1])
+			if root := lexer.Root(); root != nil {
+				checkNodeTree(t, root, false, document[:i+1])
+			}
+		}
+		root := lexer.Root()
+		assert.True(t, root.Complete)
+		var expected, actual interface{}
+		assert.Nil(t, json.Unmarshal([]byte(document), &expected))
+		assert.Nil(t, json.Unmarshal([]byte(root.Raw), &actual))
+		assert.Equal(t, expected, actual)
+		assert.Equal(t, expected, nodeToValue(root))
+	}
+}
+
+// convert complete node tree into value like json.Unmarshal() into interface{}
+func nodeToValue(node *Node) interface{} {
+	switch node.Kind {
+	case NODE_KIND_OBJECT:
+		object := map[string]interface{}{}
+		for _, child := range node.Children {
+			object[child.Key] = nodeToValue(child)
+		}
+		return object
+	case NODE_KIND_ARRAY:
+		array := []interface{}{}
+		for _, child := range node.Children {
+			array = append(array, nodeToValue(child))
+		}
+		return array
+	}
+	var value interface{}
+	json.Unmarshal([]byte(node.Raw), &value)
+	return value
+}
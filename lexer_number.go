@@ -0,0 +1,179 @@
+package streamingjsongo
+
+import (
+	"fmt"
+)
+
+// state of the number on JSON stream cursor
+type numberState struct {
+	step int // GRAMMAR_STEP_NUMBER_*, GRAMMAR_STEP_VALUE if cursor is not in a number
+}
+
+// get next step of number sub-state machine (sign, integer, fraction, exponent) by next byte,
+// false if the byte can't continue the number in given step
+func nextNumberStep(step int, c byte) (int, bool) {
+	switch step {
+	case GRAMMAR_STEP_NUMBER_SIGN:
+		switch {
+		case c == TOKEN_NUMBER_0_SYMBOL:
+			return GRAMMAR_STEP_NUMBER_ZERO, true
+		case isDigit(c):
+			return GRAMMAR_STEP_NUMBER_INTEGER, true
+		}
+	case GRAMMAR_STEP_NUMBER_ZERO, GRAMMAR_STEP_NUMBER_INTEGER:
+		switch {
+		case isDigit(c) && step == GRAMMAR_STEP_NUMBER_INTEGER:
+			return GRAMMAR_STEP_NUMBER_INTEGER, true
+		case c == TOKEN_DOT_SYMBOL:
+			return GRAMMAR_STEP_NUMBER_DOT, true
+		case c == TOKEN_ALPHABET_LOWERCASE_E_SYMBOL || c == TOKEN_ALPHABET_UPPERCASE_E_SYMBOL:
+			return GRAMMAR_STEP_NUMBER_EXPONENT, true
+		}
+	case GRAMMAR_STEP_NUMBER_DOT, GRAMMAR_STEP_NUMBER_FRACTION:
+		switch {
+		case isDigit(c):
+			return GRAMMAR_STEP_NUMBER_FRACTION, true
+		case (c == TOKEN_ALPHABET_LOWERCASE_E_SYMBOL || c == TOKEN_ALPHABET_UPPERCASE_E_SYMBOL) && step == GRAMMAR_STEP_NUMBER_FRACTION:
+			return GRAMMAR_STEP_NUMBER_EXPONENT, true
+		}
+	case GRAMMAR_STEP_NUMBER_EXPONENT:
+		switch {
+		case c == '+' || c == TOKEN_NEGATIVE_SYMBOL:
+			return GRAMMAR_STEP_NUMBER_EXPONENT_SIGN, true
+		case isDigit(c):
+			return GRAMMAR_STEP_NUMBER_EXPONENT_DIGITS, true
+		}
+	case GRAMMAR_STEP_NUMBER_EXPONENT_SIGN, GRAMMAR_STEP_NUMBER_EXPONENT_DIGITS:
+		if isDigit(c) {
+			return GRAMMAR_STEP_NUMBER_EXPONENT_DIGITS, true
+		}
+	}
+	return step, false
+}
+
+// get step of number started by given byte, GRAMMAR_STEP_VALUE if the byte can't start a number
+func getNumberStartStep(c byte) int {
+	switch {
+	case c == TOKEN_NEGATIVE_SYMBOL:
+		return GRAMMAR_STEP_NUMBER_SIGN
+	case c == TOKEN_NUMBER_0_SYMBOL:
+		return GRAMMAR_STEP_NUMBER_ZERO
+	case isDigit(c):
+		return GRAMMAR_STEP_NUMBER_INTEGER
+	}
+	return GRAMMAR_STEP_VALUE
+}
+
+// check if the byte can be in a number, like `-`, digits, `.`, `e` and `+` of `-1.5e+3`
+func isNumberByte(c byte) bool {
+	switch c {
+	case TOKEN_NEGATIVE_SYMBOL, '+', TOKEN_DOT_SYMBOL, TOKEN_ALPHABET_LOWERCASE_E_SYMBOL, TOKEN_ALPHABET_UPPERCASE_E_SYMBOL:
+		return true
+	}
+	return isDigit(c)
+}
+
+// check if number is a valid JSON number in given step, like `1`, `1.2`, `1e2`, but not `1.` or `1e`
+func isCompleteNumberStep(step int) bool {
+	switch step {
+	case GRAMMAR_STEP_NUMBER_ZERO, GRAMMAR_STEP_NUMBER_INTEGER, GRAMMAR_STEP_NUMBER_FRACTION, GRAMMAR_STEP_NUMBER_EXPONENT_DIGITS:
+		return true
+	}
+	return false
+}
+
+// get message of syntax error for the byte which can't continue the incomplete number in given step
+func invalidNumberMessage(step int, c byte) string {
+	switch step {
+	case GRAMMAR_STEP_NUMBER_SIGN:
+		return fmt.Sprintf("invalid character %q after negative sign", []byte{c})
+	case GRAMMAR_STEP_NUMBER_DOT:
+		return fmt.Sprintf("invalid character %q after decimal point of number", []byte{c})
+	}
+	return fmt.Sprintf("invalid character %q in exponent of number", []byte{c})
+}
+
+// start number by `-` or digit in value state.
+// the negative sign is not written into JSON content until the first digit, `0` is pushed into mirror stack as placeholder of it
+func (lexer *Lexer) startNumber(c byte) {
+	// check if json stream stopped with padding content, like `[1 , 1`
+	lexer.flushPaddingContent()
+	if lexer.state == STREAM_STATE_OBJECT_VALUE {
+		// pop `n`, `u`, `l`, `l` from mirror stack
+		lexer.popMirrorTokens(MIRROR_PLACEHOLDER_OBJECT_VALUE_LENGTH)
+	}
+	lexer.state = STREAM_STATE_NUMBER
+	lexer.number.step = getNumberStartStep(c)
+	if c == TOKEN_NEGATIVE_SYMBOL {
+		lexer.startPathValue(lexer.JSONContent.Len(), TOKEN_NEGATIVE)
+		lexer.pushTokenStack(TOKEN_NEGATIVE)
+		lexer.pushMirrorTokenStack(TOKEN_NUMBER_0)
+		return
+	}
+	lexer.JSONContent.WriteByte(c)
+	lexer.pushTokenStack(TOKEN_NUMBER)
+	lexer.startPathValue(lexer.JSONContent.Len()-1, TOKEN_NUMBER)
+}
+
+// continue number by next byte of number grammar, the byte can't continue the number is dropped, like the second `.` of `1.2.3` and `1` of `01`.
+// the decimal point and the exponent are kept in padding content until their first digit, so the partial number completes as a valid number, like `1.` of `1.5`
+func (lexer *Lexer) continueNumber(c byte) {
+	number := &lexer.number
+	step := number.step
+	next, ok := nextNumberStep(step, c)
+	if !ok {
+		return
+	}
+	number.step = next
+	lexer.validateNumberStep(next)
+
+	switch next {
+	case GRAMMAR_STEP_NUMBER_DOT:
+		lexer.pushTokenStack(TOKEN_DOT)
+		lexer.pushByteIntoPaddingContent(c)
+	case GRAMMAR_STEP_NUMBER_EXPONENT, GRAMMAR_STEP_NUMBER_EXPONENT_SIGN:
+		// the exponent is written into JSON content with its first digit
+		lexer.pushByteIntoPaddingContent(c)
+	default:
+		// check if json stream stopped with padding content, like `1.` of `1.5` or `1e` of `1e5`
+		lexer.flushPaddingContent()
+		if step == GRAMMAR_STEP_NUMBER_SIGN {
+			lexer.JSONContent.WriteByte(TOKEN_NEGATIVE_SYMBOL)
+			// pop placeholder `0` of negative sign
+			lexer.popMirrorTokenStack()
+		}
+		lexer.JSONContent.WriteByte(c)
+		if step == GRAMMAR_STEP_NUMBER_SIGN || step == GRAMMAR_STEP_NUMBER_DOT {
+			lexer.pushTokenStack(TOKEN_NUMBER)
+		}
+	}
+}
+
+// continue number by the digits in JSON segment from offset, returns offset of the first byte after them.
+// only the digits written into JSON content as they are, in integer, fraction or exponent of number
+func (lexer *Lexer) continueDigitsInSegment(str string, offset int) int {
+	switch lexer.number.step {
+	case GRAMMAR_STEP_NUMBER_INTEGER, GRAMMAR_STEP_NUMBER_FRACTION, GRAMMAR_STEP_NUMBER_EXPONENT_DIGITS:
+	default:
+		return offset
+	}
+	digitsEnd := offset
+	for digitsEnd < len(str) && isDigit(str[digitsEnd]) {
+		digitsEnd++
+	}
+	lexer.JSONContent.WriteString(str[offset:digitsEnd])
+	return digitsEnd
+}
+
+// end the number on JSON stream cursor, the decimal point or exponent without digit is dropped, like `.` of `[1.]` and `e` of `[1e]`,
+// and the negative sign without digit is dropped with its placeholder `0`, like `-` of `[-]`
+func (lexer *Lexer) endNumber() {
+	number := &lexer.number
+	switch number.step {
+	case GRAMMAR_STEP_NUMBER_SIGN:
+		lexer.popMirrorTokenStack()
+	case GRAMMAR_STEP_NUMBER_DOT, GRAMMAR_STEP_NUMBER_EXPONENT, GRAMMAR_STEP_NUMBER_EXPONENT_SIGN:
+		lexer.cleanPaddingContent()
+	}
+	number.step = GRAMMAR_STEP_VALUE
+}
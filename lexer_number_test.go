@@ -0,0 +1,80 @@
+package streamingjsongo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompleteJSON_partialNumbers(t *testing.T) {
+	streamingJSONCase := map[string]string{
+		`[1.`:           `[1]`,
+		`[1.]`:          `[1]`,
+		`[1.,2`:         `[1,2]`,
+		`[1e`:           `[1]`,
+		`[1e-`:          `[1]`,
+		`[1.5E+`:        `[1.5]`,
+		`[-`:            `[0]`,
+		`[-0`:           `[-0]`,
+		`[-0.`:          `[-0]`,
+		`[-0.00`:        `[-0.00]`,
+		`[-0e`:          `[-0]`,
+		`[-0.0E-`:       `[-0.0]`,
+		`[-0.05`:        `[-0.05]`,
+		`[-0e1`:         `[-0e1]`,
+		`[-0,`:          `[-0]`,
+		`[-0 `:          `[-0]`,
+		`[-0.0 `:        `[-0.0]`,
+		`[-0e,`:         `[-0]`,
+		`[-0 , 1`:       `[-0 , 1]`,
+		`[-0]`:          `[-0]`,
+		`{"a":-0.`:      `{"a":-0}`,
+		`{"a":-0, "b`:   `{"a":-0, "b":null}`,
+		`{"a":[-0.0,-1`: `{"a":[-0.0,-1]}`,
+	}
+	for testCase, expect := range streamingJSONCase {
+		lexer := NewLexer()
+		assert.Nil(t, lexer.AppendString(testCase), testCase)
+		assert.Equal(t, expect, lexer.CompleteJSON(), testCase)
+	}
+}
+
+func TestCompleteJSON_invalidNumbers(t *testing.T) {
+	// the byte can't continue the number is dropped, and the exponent without digit is dropped when the number ends
+	streamingJSONCase := map[string]string{
+		`[1.2.3`:      `[1.23]`,
+		`[01`:         `[0]`,
+		`[-01]`:       `[-0]`,
+		`[--1`:        `[-1]`,
+		`[1ee5]`:      `[1e5]`,
+		`[1-2, 3`:     `[12, 3]`,
+		`[1e]`:        `[1]`,
+		`[1e-,2`:      `[1,2]`,
+		`{"a":1.5.5}`: `{"a":1.55}`,
+		`[1 2`:        `[1]`,
+		`[1 2]`:       `[1 ]`,
+		`[1,2 -3.5,4`: `[1,2 ,4]`,
+		`{"a":1 2}`:   `{"a":1 }`,
+	}
+	for testCase, expect := range streamingJSONCase {
+		lexer := NewLexer()
+		assert.Nil(t, lexer.AppendString(testCase), testCase)
+		assert.Equal(t, expect, lexer.CompleteJSON(), testCase)
+	}
+}
+
+func TestCompleteJSON_everyPartialNumber(t *testing.T) {
+	document := `[0, -0, -0.0, -0.001e-2, 12.5E+3, -7e0, {"a": -0.00E1}]`
+	lexer := NewLexer()
+	for i := range document {
+		assert.Nil(t, lexer.AppendString(document[i:i+1]))
+		completed := lexer.CompleteJSON()
+		assert.True(t, json.Valid([]byte(completed)), completed)
+		// the digit is stable as it arrives, so the completed number never flips, like `0` of `[-0` to `-0` of `[-0,`
+		if isDigit(document[i]) {
+			assert.Equal(t, document[:i+1], lexer.Completion().Stable)
+		}
+	}
+	assert.Equal(t, document, lexer.CompleteJSON())
+}
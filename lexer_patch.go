@@ -0,0 +1,153 @@
+package streamingjsongo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+// patch operation const
+const (
+	PATCH_OPERATION_ADD     = "add"
+	PATCH_OPERATION_REMOVE  = "remove"
+	PATCH_OPERATION_REPLACE = "replace"
+	PATCH_OPERATION_APPEND  = "append" // non-standard compact operation, append value string to the string at path
+)
+
+// JSON Patch (RFC 6902) operation describing how the completed JSON changed
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// completion state at last patch generated
+type patchBase struct {
+	rootStarted bool
+	pathFrames  []pathFrame
+	contentLen  int
+	tail        string // mirror tokens in string
+}
+
+// generate JSON Patch (RFC 6902) describing how the completed JSON changed since last call
+func (lexer *Lexer) CompletionPatch() ([]PatchOperation, error) {
+	return lexer.completionPatch(false)
+}
+
+// generate JSON Patch like CompletionPatch(), but use compact `append` operation for growing string
+func (lexer *Lexer) CompactCompletionPatch() ([]PatchOperation, error) {
+	return lexer.completionPatch(true)
+}
+
+// generate patch by the container frames on the path of JSON stream cursor,
+// only the members of the deepest container which is on both previous and current cursor path will be compared.
+func (lexer *Lexer) completionPatch(compact bool) ([]PatchOperation, error) {
+	if lexer.flush.writer != nil {
+		return nil, ErrContentFlushed
+	}
+	content := lexer.JSONContent.String()
+	tail := lexer.completeJSONTail()
+	base := lexer.patchBase
+	lexer.patchBase = patchBase{
+		rootStarted: lexer.rootStarted,
+		pathFrames:  append([]pathFrame(nil), lexer.pathFrames...),
+		contentLen:  len(content),
+		tail:        tail,
+	}
+
+	if !lexer.rootStarted {
+		return nil, nil
+	}
+	completed := content + tail
+	if !base.rootStarted {
+		return []PatchOperation{{Op: PATCH_OPERATION_ADD, Path: "", Value: json.RawMessage(completed)}}, nil
+	}
+
+	// find the deepest common container frame
+	common := 0
+	for common < len(base.pathFrames) && common < len(lexer.pathFrames) && base.pathFrames[common].start == lexer.pathFrames[common].start {
+		common++
+	}
+	var previousFrame, currentFrame pathFrame
+	pointer := ""
+	switch {
+	case common > 0:
+		previousFrame = base.pathFrames[common-1]
+		currentFrame = lexer.pathFrames[common-1]
+		pointer = pathFramesToJSONPointer(lexer.pathFrames[:common-1], content)
+	case len(base.pathFrames) > 0:
+		// root container closed since last patch
+		previousFrame = base.pathFrames[0]
+		currentFrame = lexer.lastClosedPathFrame
+	default:
+		// root container closed before last patch, completion will not change any more
+		return nil, nil
+	}
+	previous := content[:base.contentLen] + base.tail
+	return diffContainerMembers(previous, completed, base.contentLen, pointer, previousFrame, currentFrame, compact)
+}
+
+// diff members of the same container in previous and current completed JSON.
+// only the last member in previous container can be changed, the others are appended.
+func diffContainerMembers(previous string, current string, previousContentLen int, pointer string, previousFrame pathFrame, currentFrame pathFrame, compact bool) ([]PatchOperation, error) {
+	var operations []PatchOperation
+	isArray := currentFrame.isArray
+	memberPointer := func(index int, key string) string {
+		if isArray {
+			return pointer + "/" + strconv.Itoa(index)
+		}
+		return pointer + "/" + escapeJSONPointerToken(key)
+	}
+	index := 0
+	offset := skipJSONWhitespace(current, currentFrame.start+1)
+	if previousFrame.members > 0 {
+		index = previousFrame.members - 1
+		previousKey, previousValueStart, previousValueEnd, _, previousOK := scanJSONMember(previous, previousFrame.memberStart, isArray)
+		key, valueStart, valueEnd, next, ok := scanJSONMember(current, previousFrame.memberStart, isArray)
+		if !previousOK || !ok {
+			return nil, fmt.Errorf("invalid completed JSON at `%s`", memberPointer(index, key))
+		}
+		previousValue := previous[previousValueStart:previousValueEnd]
+		value := current[valueStart:valueEnd]
+		switch {
+		case previousKey != key:
+			operations = append(operations, PatchOperation{Op: PATCH_OPERATION_REMOVE, Path: memberPointer(index, previousKey)})
+			operations = append(operations, PatchOperation{Op: PATCH_OPERATION_ADD, Path: memberPointer(index, key), Value: json.RawMessage(value)})
+		case previousValue == value:
+			// not changed
+		case compact && previousValueStart == valueStart && previousValueEnd == previousContentLen+1 && value[0] == TOKEN_QUOTE_SYMBOL && previousValue[0] == TOKEN_QUOTE_SYMBOL && isUTF8Boundary(current, previousContentLen):
+			// the string was streaming at last patch, the new content appended to it
+			appended := `"` + current[previousContentLen:valueEnd-1] + `"`
+			operations = append(operations, PatchOperation{Op: PATCH_OPERATION_APPEND, Path: memberPointer(index, key), Value: json.RawMessage(appended)})
+		default:
+			operations = append(operations, PatchOperation{Op: PATCH_OPERATION_REPLACE, Path: memberPointer(index, key), Value: json.RawMessage(value)})
+		}
+		index++
+		offset = next
+	}
+	// appended members
+	for offset < len(current) && current[offset] != TOKEN_RIGHT_BRACE_SYMBOL && current[offset] != TOKEN_RIGHT_BRACKET_SYMBOL {
+		key, valueStart, valueEnd, next, ok := scanJSONMember(current, offset, isArray)
+		if !ok {
+			return nil, fmt.Errorf("invalid completed JSON at `%s`", memberPointer(index, key))
+		}
+		operations = append(operations, PatchOperation{Op: PATCH_OPERATION_ADD, Path: memberPointer(index, key), Value: json.RawMessage(current[valueStart:valueEnd])})
+		index++
+		offset = next
+	}
+	return operations, nil
+}
+
+// check if offset of s is not in the middle of an UTF-8 sequence
+func isUTF8Boundary(s string, offset int) bool {
+	if offset >= len(s) {
+		return true
+	}
+	tailStart := offset - utf8.UTFMax
+	if tailStart < 0 {
+		tailStart = 0
+	}
+	tail := []byte(s[tailStart:offset])
+	return utf8.RuneStart(s[offset]) && incompleteUTF8SuffixStart(tail) == len(tail)
+}
@@ -0,0 +1,132 @@
+package streamingjsongo
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// apply patch operations to decoded JSON document, for test only
+func applyPatchOperations(t *testing.T, document interface{}, operations []PatchOperation) interface{} {
+	for _, operation := range operations {
+		var value interface{}
+		if operation.Op != PATCH_OPERATION_REMOVE {
+			if !assert.Nil(t, json.Unmarshal(operation.Value, &value), "invalid patch value: %s", operation.Value) {
+				return document
+			}
+		}
+		if operation.Path == "" {
+			document = value
+			continue
+		}
+		tokens := strings.Split(operation.Path[1:], "/")
+		var parent interface{}
+		container := document
+		for _, token := range tokens[:len(tokens)-1] {
+			token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+			parent = container
+			switch c := container.(type) {
+			case map[string]interface{}:
+				container = c[token]
+			case []interface{}:
+				index, _ := strconv.Atoi(token)
+				container = c[index]
+			}
+		}
+		last := strings.NewReplacer("~1", "/", "~0", "~").Replace(tokens[len(tokens)-1])
+		switch c := container.(type) {
+		case map[string]interface{}:
+			switch operation.Op {
+			case PATCH_OPERATION_REMOVE:
+				delete(c, last)
+			case PATCH_OPERATION_APPEND:
+				c[last] = c[last].(string) + value.(string)
+			default:
+				c[last] = value
+			}
+		case []interface{}:
+			index, _ := strconv.Atoi(last)
+			switch operation.Op {
+			case PATCH_OPERATION_ADD:
+				c = append(c, value)
+			case PATCH_OPERATION_APPEND:
+				c[index] = c[index].(string) + value.(string)
+			default:
+				c[index] = value
+			}
+			// re-attach the grown array to its parent
+			if len(tokens) == 1 {
+				document = c
+			} else {
+				parentToken := strings.NewReplacer("~1", "/", "~0", "~").Replace(tokens[len(tokens)-2])
+				switch p := parent.(type) {
+				case map[string]interface{}:
+					p[parentToken] = c
+				case []interface{}:
+					parentIndex, _ := strconv.Atoi(parentToken)
+					p[parentIndex] = c
+				}
+			}
+		}
+	}
+	return document
+}
+
+func testCompletionPatchByChunkSize(t *testing.T, content string, chunkSize int, compact bool) {
+	lexer := NewLexer()
+	var document interface{}
+	for i := 0; i < len(content); i += chunkSize {
+		end := i + chunkSize
+		if end > len(content) {
+			end = len(content)
+		}
+		assert.Nil(t, lexer.AppendString(content[i:end]))
+		var operations []PatchOperation
+		var err error
+		if compact {
+			operations, err = lexer.CompactCompletionPatch()
+		} else {
+			operations, err = lexer.CompletionPatch()
+		}
+		assert.Nil(t, err)
+		document = applyPatchOperations(t, document, operations)
+		var expect interface{}
+		assert.Nil(t, json.Unmarshal([]byte(lexer.CompleteJSON()), &expect))
+		if !assert.Equal(t, expect, document, "unexpected patched document at `%s`", content[:end]) {
+			return
+		}
+	}
+}
+
+func TestCompletionPatch_applied(t *testing.T) {
+	content := `{"string": "这是一个字符串\"A", "integer": -42, "float": 3.14159, "boolean_true": true, "null": null, "a/b~c": 1, "object": {"empty_object": {}, "nested_object": {"nested_key": {"sub_nested_key": "sub_nested_value"}}}, "array":["string in array", 123, 45.67, true, false, null, {"object_in_array": "object_value"},["nested_array", [1, -2]]]}`
+	for _, chunkSize := range []int{1, 2, 3, 7, 16, 1000} {
+		testCompletionPatchByChunkSize(t, content, chunkSize, false)
+		testCompletionPatchByChunkSize(t, content, chunkSize, true)
+	}
+}
+
+func TestCompletionPatch_operations(t *testing.T) {
+	lexer := NewLexer()
+	steps := []struct {
+		segment    string
+		operations string
+	}{
+		{`{"a`, `[{"op":"add","path":"","value":{"a":null}}]`},
+		{`b":"x`, `[{"op":"remove","path":"/a"},{"op":"add","path":"/ab","value":"x"}]`},
+		{`yz`, `[{"op":"append","path":"/ab","value":"yz"}]`},
+		{`", "c":[1,`, `[{"op":"add","path":"/c","value":[1]}]`},
+		{` t`, `[{"op":"add","path":"/c/1","value":true}]`},
+		{`rue]}`, `null`},
+	}
+	for _, step := range steps {
+		assert.Nil(t, lexer.AppendString(step.segment))
+		operations, err := lexer.CompactCompletionPatch()
+		assert.Nil(t, err)
+		operationsInJSON, _ := json.Marshal(operations)
+		assert.Equal(t, step.operations, string(operationsInJSON), "unexpected patch after `%s`", step.segment)
+	}
+}
@@ -0,0 +1,228 @@
+package streamingjsongo
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// container (object or array) frame on the path of JSON stream cursor
+type pathFrame struct {
+	isArray     bool
+	start       int      // offset of `{` or `[` in JSONContent
+	members     int      // count of members (properity or element) appeared in container
+	memberStart int      // offset of last member in JSONContent, the key quote for object, the value for array
+	keyStart    int      // offset of last properity key content in JSONContent
+	keyEnd      int      // offset of last properity key content end in JSONContent, -1 if key not finished
+	valueStart  int      // offset of last member value in JSONContent
+	schema      *Schema  // schema of container, nil if no schema attached
+	keys        []string // finished properity keys declared by schema, only tracked with schema attached
+	key         string   // decoded last properity key, only kept in bounded-memory mode since the key content is flushed
+	keyKept     bool     // last properity key is kept in key
+}
+
+// open a container frame at given JSONContent offset
+func (lexer *Lexer) openPathFrame(isArray bool, offset int, token int) {
+	if len(lexer.pathFrames) == 0 {
+		if lexer.rootStarted {
+			// only the first root container is tracked
+			return
+		}
+		lexer.rootStarted = true
+		lexer.validateValueStart(token)
+	} else {
+		lexer.startPathValue(offset, token)
+	}
+	lexer.pathFrames = append(lexer.pathFrames, pathFrame{isArray: isArray, start: offset, keyEnd: -1, schema: lexer.getCurrentValueSchema()})
+}
+
+// close the container frame on the path top
+func (lexer *Lexer) closePathFrame() {
+	pathFramesLen := len(lexer.pathFrames)
+	if pathFramesLen == 0 {
+		return
+	}
+	lexer.lastClosedPathFrame = lexer.pathFrames[pathFramesLen-1]
+	lexer.pathFrames = lexer.pathFrames[:pathFramesLen-1]
+}
+
+// get the container frame on the path top, nil if cursor not in a container
+func (lexer *Lexer) getTopPathFrame() *pathFrame {
+	pathFramesLen := len(lexer.pathFrames)
+	if pathFramesLen == 0 {
+		return nil
+	}
+	return &lexer.pathFrames[pathFramesLen-1]
+}
+
+// a value (array element or object properity value) started at given JSONContent offset by given token
+func (lexer *Lexer) startPathValue(offset int, token int) {
+	frame := lexer.getTopPathFrame()
+	if frame == nil {
+		return
+	}
+	frame.valueStart = offset
+	if frame.isArray {
+		frame.members++
+		frame.memberStart = offset
+	}
+	lexer.validateValueStart(token)
+}
+
+// an object properity key started, the given JSONContent offset is the key quote
+func (lexer *Lexer) startPathObjectKey(offset int) {
+	frame := lexer.getTopPathFrame()
+	if frame == nil || frame.isArray {
+		return
+	}
+	frame.members++
+	frame.memberStart = offset
+	frame.keyStart = offset + 1
+	frame.keyEnd = -1
+	frame.keyKept = false
+}
+
+// an object properity key finished, the given JSONContent offset is the key quote
+func (lexer *Lexer) endPathObjectKey(offset int) {
+	frame := lexer.getTopPathFrame()
+	if frame == nil || frame.isArray {
+		return
+	}
+	frame.keyEnd = offset
+	frame.keyKept = false
+	if lexer.flush.writer != nil {
+		frame.key = cloneString(frame.lastKey(lexer.JSONContent.String()))
+		frame.keyKept = true
+	}
+	if frame.schema != nil {
+		// only the declared keys are needed by completion, so the keys stay O(properties) rather than O(document)
+		key := frame.lastKey(lexer.JSONContent.String())
+		if schema := frame.schema.resolve(); schema != nil {
+			if name, propertySchema := schema.lookupProperty(key); propertySchema != nil && !containsString(frame.keys, name) {
+				frame.keys = append(frame.keys, name)
+			}
+		}
+		lexer.validateObjectKey(frame, true)
+	}
+}
+
+// get schema of the current value on the cursor, nil if no schema attached
+func (lexer *Lexer) getCurrentValueSchema() *Schema {
+	frame := lexer.getTopPathFrame()
+	if frame == nil {
+		return lexer.schema
+	}
+	if frame.schema == nil {
+		return nil
+	}
+	if frame.isArray {
+		return frame.schema.getItemSchema(frame.members - 1)
+	}
+	return frame.schema.getPropertySchema(frame.lastKey(lexer.JSONContent.String()))
+}
+
+// get the decoded last properity key of object frame from JSON content
+func (frame *pathFrame) lastKey(content string) string {
+	if frame.keyKept {
+		return frame.key
+	}
+	keyEnd := frame.keyEnd
+	if keyEnd < 0 {
+		keyEnd = len(content)
+	}
+	return decodeJSONStringContent(content[frame.keyStart:keyEnd])
+}
+
+// get JSON pointer (RFC 6901) of last member in given frames
+func pathFramesToJSONPointer(frames []pathFrame, content string) string {
+	var pointer strings.Builder
+	for i := range frames {
+		pointer.WriteByte('/')
+		if frames[i].isArray {
+			pointer.WriteString(strconv.Itoa(frames[i].members - 1))
+			continue
+		}
+		pointer.WriteString(escapeJSONPointerToken(frames[i].lastKey(content)))
+	}
+	return pointer.String()
+}
+
+// get JSON pointer (RFC 6901) of the value on JSON stream cursor, like `/steps/1/title`, or the innermost container before its first member.
+// the last reference token is partial if the properity key is still streaming.
+func (lexer *Lexer) CurrentPath() string {
+	frames := lexer.pathFrames
+	if framesLen := len(frames); framesLen > 0 && frames[framesLen-1].members == 0 {
+		frames = frames[:framesLen-1]
+	}
+	return pathFramesToJSONPointer(frames, lexer.JSONContent.String())
+}
+
+// escape JSON pointer reference token, `~` to `~0` and `/` to `~1`
+func escapeJSONPointerToken(token string) string {
+	if !strings.ContainsAny(token, "~/") {
+		return token
+	}
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// decode JSON string content (without quotes), return the raw content if it is not decodable
+func decodeJSONStringContent(raw string) string {
+	if !strings.ContainsRune(raw, TOKEN_ESCAPE_CHARACTER_SYMBOL) {
+		return raw
+	}
+	var decoded string
+	if err := json.Unmarshal([]byte(`"`+raw+`"`), &decoded); err != nil {
+		return raw
+	}
+	return decoded
+}
+
+// parse JSON pointer (RFC 6901) into reference tokens, `~1` to `/` and `~0` to `~`
+func parseJSONPointer(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range tokens {
+		if strings.Contains(token, "~") {
+			tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		}
+	}
+	return tokens
+}
+
+// find value node by reference tokens of JSON pointer, the first properity is used if keys are duplicated.
+// returns nil if the node did not arrive yet, and missing if it never arrives because the container on path is complete or not matched.
+func findNodeByJSONPointer(root *Node, tokens []string) (node *Node, missing bool) {
+	node = root
+	for _, token := range tokens {
+		if node == nil {
+			return nil, false
+		}
+		var child *Node
+		switch node.Kind {
+		case NODE_KIND_OBJECT:
+			for _, member := range node.Children {
+				if member.Key == token {
+					child = member
+					break
+				}
+			}
+		case NODE_KIND_ARRAY:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 {
+				return nil, true
+			}
+			if index < len(node.Children) {
+				child = node.Children[index]
+			}
+		default:
+			return nil, true
+		}
+		if child == nil && node.Complete {
+			return nil, true
+		}
+		node = child
+	}
+	return node, false
+}
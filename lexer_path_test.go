@@ -0,0 +1,25 @@
+package streamingjsongo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrentPath(t *testing.T) {
+	streamingJSONCase := map[string]string{
+		``:                                 ``,
+		`{`:                                ``,
+		`{"ste`:                            `/ste`,
+		`{"steps": [`:                      `/steps`,
+		`{"steps": [{"title": "a"}, {"ti`:  `/steps/1/ti`,
+		`{"steps": [{"title": "a"}, {}, 1`: `/steps/2`,
+		`{"a/b~": {"c": "`:                 `/a~1b~0/c`,
+		`{"a": 1}`:                         ``,
+	}
+	for testCase, expected := range streamingJSONCase {
+		lexer := NewLexer()
+		lexer.AppendString(testCase)
+		assert.Equal(t, expected, lexer.CurrentPath(), testCase)
+	}
+}
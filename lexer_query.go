@@ -0,0 +1,160 @@
+package streamingjsongo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// segment of JSONPath, selects children (or descendants) of the matched nodes
+type jsonPathSegment struct {
+	descendant bool   // recursive descent `..`, selects from the node and all its descendants
+	wildcard   bool   // `*` selects all children
+	isIndex    bool   // index selector like `[0]`, or name selector like `.name` or `['name']`
+	index      int    // array index of index selector
+	name       string // properity key of name selector
+}
+
+// query the value nodes matched by JSONPath on the partial JSON stream, like `$.steps[*].title`.
+// the supported subset is root `$`, child `.name` `['name']`, index `[0]`, wildcard `.*` `[*]` and recursive descent `..name` `..*`.
+// the nodes are in document order, and may be partial, check Node.Complete. more nodes may match as the JSON stream arrives
+// unless the containers on the path are complete.
+func (lexer *Lexer) Query(path string) ([]*Node, error) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if lexer.flush.writer != nil {
+		return nil, ErrContentFlushed
+	}
+	root := lexer.Root()
+	if root == nil {
+		return nil, nil
+	}
+	nodes := []*Node{root}
+	for _, segment := range segments {
+		var selected []*Node
+		for _, node := range nodes {
+			selected = segment.selectChildren(selected, node)
+		}
+		nodes = selected
+	}
+	return nodes, nil
+}
+
+// append the children of node selected by segment, and the selected descendants of node for recursive descent, in document order
+func (segment *jsonPathSegment) selectChildren(selected []*Node, node *Node) []*Node {
+	for i, child := range node.Children {
+		if segment.selects(node, i, child) {
+			selected = append(selected, child)
+		}
+		if segment.descendant {
+			selected = segment.selectChildren(selected, child)
+		}
+	}
+	return selected
+}
+
+// check if the i-th child of node is selected by segment
+func (segment *jsonPathSegment) selects(node *Node, i int, child *Node) bool {
+	switch {
+	case segment.wildcard:
+		return true
+	case segment.isIndex:
+		return node.Kind == NODE_KIND_ARRAY && i == segment.index
+	}
+	return node.Kind == NODE_KIND_OBJECT && child.Key == segment.name
+}
+
+// parse JSONPath into segments
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("invalid JSONPath `%s`: must start with `$`", path)
+	}
+	var segments []jsonPathSegment
+	for i := 1; i < len(path); {
+		var segment jsonPathSegment
+		switch {
+		case strings.HasPrefix(path[i:], ".."):
+			segment.descendant = true
+			i += 2
+		case path[i] == '.':
+			i++
+		case path[i] != '[':
+			return nil, fmt.Errorf("invalid JSONPath `%s`: unexpected character %q at offset %d", path, path[i], i)
+		}
+		var err error
+		if i < len(path) && path[i] == '[' && (segment.descendant || path[i-1] != '.') {
+			i, err = segment.parseBracket(path, i)
+		} else {
+			i, err = segment.parseDotName(path, i)
+		}
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+// parse dot selector name like `name` or `*` at offset i, returns offset after it
+func (segment *jsonPathSegment) parseDotName(path string, i int) (int, error) {
+	end := i
+	for end < len(path) && path[end] != '.' && path[end] != '[' {
+		end++
+	}
+	switch path[i:end] {
+	case "":
+		return 0, fmt.Errorf("invalid JSONPath `%s`: missing name at offset %d", path, i)
+	case "*":
+		segment.wildcard = true
+	default:
+		segment.name = path[i:end]
+	}
+	return end, nil
+}
+
+// parse bracket selector like `[0]`, `[*]` or `['name']` at offset i, returns offset after it
+func (segment *jsonPathSegment) parseBracket(path string, i int) (int, error) {
+	i++
+	if i < len(path) && (path[i] == '\'' || path[i] == '"') {
+		quote := path[i]
+		var name strings.Builder
+		for i++; i < len(path) && path[i] != quote; i++ {
+			// keep the escape sequences except escaped quotes, and escape `"`, then decode it like JSON string content
+			c := path[i]
+			if c == '\\' && i+1 < len(path) {
+				i++
+				if path[i] != '\'' && path[i] != '"' {
+					name.WriteByte('\\')
+				}
+				c = path[i]
+			}
+			if c == '"' {
+				name.WriteByte('\\')
+			}
+			name.WriteByte(c)
+		}
+		if i+1 >= len(path) || path[i+1] != ']' {
+			return 0, fmt.Errorf("invalid JSONPath `%s`: unclosed name selector", path)
+		}
+		segment.name = decodeJSONStringContent(name.String())
+		return i + 2, nil
+	}
+	end := strings.IndexByte(path[i:], ']')
+	if end < 0 {
+		return 0, fmt.Errorf("invalid JSONPath `%s`: unclosed bracket at offset %d", path, i-1)
+	}
+	selector := path[i : i+end]
+	if selector == "*" {
+		segment.wildcard = true
+		return i + end + 1, nil
+	}
+	index, err := strconv.Atoi(selector)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("invalid JSONPath `%s`: unsupported selector `[%s]`, only non-negative index, `*` and quoted name are supported", path, selector)
+	}
+	segment.isIndex = true
+	segment.index = index
+	return i + end + 1, nil
+}
@@ -0,0 +1,98 @@
+package streamingjsongo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// raw JSON and completeness of query result nodes
+type queryResult struct {
+	Raw      string
+	Complete bool
+}
+
+func queryResults(t *testing.T, lexer *Lexer, path string) []queryResult {
+	nodes, err := lexer.Query(path)
+	assert.Nil(t, err, path)
+	var results []queryResult
+	for _, node := range nodes {
+		results = append(results, queryResult{node.Raw, node.Complete})
+	}
+	return results
+}
+
+func TestQuery(t *testing.T) {
+	lexer := NewLexer()
+	assert.Nil(t, queryResults(t, lexer, "$.steps[*].title"))
+
+	lexer.AppendString(`{"steps": [{"title": "plan", "n": 1}, {"title": "wri`)
+	testCases := []struct {
+		path     string
+		expected []queryResult
+	}{
+		{`$.steps[*].title`, []queryResult{{`"plan"`, true}, {`"wri`, false}}},
+		{`$['steps'][1]["title"]`, []queryResult{{`"wri`, false}}},
+		{`$.steps[2].title`, nil},
+		{`$..title`, []queryResult{{`"plan"`, true}, {`"wri`, false}}},
+		{`$.steps[0].*`, []queryResult{{`"plan"`, true}, {`1`, true}}},
+		{`$..*`, []queryResult{
+			{`[{"title": "plan", "n": 1}, {"title": "wri`, false},
+			{`{"title": "plan", "n": 1}`, true},
+			{`"plan"`, true},
+			{`1`, true},
+			{`{"title": "wri`, false},
+			{`"wri`, false},
+		}},
+		{`$`, []queryResult{{lexer.JSONContent.String(), false}}},
+		{`$.steps.title`, nil},
+		{`$.missing`, nil},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, queryResults(t, lexer, testCase.path), testCase.path)
+	}
+
+	// the results are updated as JSON stream arrives
+	lexer.AppendString(`te"}, {"title": "test"}]}`)
+	assert.Equal(t, []queryResult{{`"plan"`, true}, {`"write"`, true}, {`"test"`, true}}, queryResults(t, lexer, "$.steps[*].title"))
+}
+
+func TestQuery_quotedName(t *testing.T) {
+	lexer := NewLexer()
+	lexer.AppendString(`{"a.b": 1, "it's": 2, "say \"hi\"": 3, "a\\b": 4, "你": 5}`)
+	testCases := map[string]string{
+		`$['a.b']`:        `1`,
+		`$['it\'s']`:      `2`,
+		`$["it's"]`:       `2`,
+		`$['say "hi"']`:   `3`,
+		`$["say \"hi\""]`: `3`,
+		`$['a\\b']`:       `4`,
+		`$['你']`:          `5`,
+		`$..['你']`:        `5`,
+	}
+	for path, expected := range testCases {
+		assert.Equal(t, []queryResult{{expected, true}}, queryResults(t, lexer, path), path)
+	}
+}
+
+func TestQuery_error(t *testing.T) {
+	testCases := map[string]string{
+		`steps`:     "invalid JSONPath `steps`: must start with `$`",
+		`$steps`:    "invalid JSONPath `$steps`: unexpected character 's' at offset 1",
+		`$.`:        "invalid JSONPath `$.`: missing name at offset 2",
+		`$.[0]`:     "invalid JSONPath `$.[0]`: missing name at offset 2",
+		`$..`:       "invalid JSONPath `$..`: missing name at offset 3",
+		`$[0`:       "invalid JSONPath `$[0`: unclosed bracket at offset 1",
+		`$['a]`:     "invalid JSONPath `$['a]`: unclosed name selector",
+		`$[-1]`:     "invalid JSONPath `$[-1]`: unsupported selector `[-1]`, only non-negative index, `*` and quoted name are supported",
+		`$[?(@.a)]`: "invalid JSONPath `$[?(@.a)]`: unsupported selector `[?(@.a)]`, only non-negative index, `*` and quoted name are supported",
+	}
+	lexer := NewLexer()
+	lexer.AppendString(`{"a": 1}`)
+	for path, expected := range testCases {
+		_, err := lexer.Query(path)
+		if assert.NotNil(t, err, path) {
+			assert.Equal(t, expected, err.Error(), path)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package streamingjsongo
+
+import (
+	"strings"
+)
+
+// get tail of completed JSON, guided by schema if attached
+func (lexer *Lexer) completeJSONTail() string {
+	if lexer.schema == nil {
+		return lexer.dumpMirrorTokenStackToString()
+	}
+	return lexer.dumpSchemaGuidedTail()
+}
+
+// check if mirror stack segment is exactly the given tokens
+func isMirrorSegment(segment []int, tokens ...int) bool {
+	if len(segment) != len(tokens) {
+		return false
+	}
+	for i, token := range tokens {
+		if segment[i] != token {
+			return false
+		}
+	}
+	return true
+}
+
+// convert mirror stack segment into string
+func dumpMirrorSegmentToString(builder *strings.Builder, segment []int) {
+	for i := len(segment) - 1; i >= 0; i-- {
+		builder.WriteString(tokenSymbolMap[segment[i]])
+	}
+}
+
+// write suffix of s after prefix as JSON string content without quotes
+func writeJSONStringSuffix(builder *strings.Builder, s string, prefix string) {
+	encoded := encodeJSONString(s[len(prefix):])
+	builder.WriteString(encoded[1 : len(encoded)-1])
+}
+
+// get segment of mirror stack above the closer of innermost container, which is the placeholder of current value.
+// false if cursor is not in a container, or the closers in mirror stack don't belong to the container frames
+func (lexer *Lexer) getMirrorTopSegment() ([]int, bool) {
+	segmentStart := 0
+	closers := 0
+	for i, token := range lexer.MirrorTokenStack {
+		if token == TOKEN_RIGHT_BRACE || token == TOKEN_RIGHT_BRACKET {
+			closers++
+			segmentStart = i + 1
+		}
+	}
+	if closers == 0 || closers != len(lexer.pathFrames) {
+		return nil, false
+	}
+	return lexer.MirrorTokenStack[segmentStart:], true
+}
+
+// check if mirror stack segment is placeholder of properity key in progress, like `{"fie`
+func isObjectKeyPlaceholderSegment(segment []int) bool {
+	return isMirrorSegment(segment, MIRROR_PLACEHOLDER_OBJECT_KEY...)
+}
+
+// build tail of completed JSON from mirror stack guided by schema.
+// the closers in mirror stack belong to the container frames in order, the segment above the last closer is placeholder of current value.
+// dangling keys get type-correct placeholders, partial keys and strings are completed by properity names and enum,
+// and required-but-missing properties are filled before the closer of each object.
+func (lexer *Lexer) dumpSchemaGuidedTail() string {
+	segment, ok := lexer.getMirrorTopSegment()
+	if !ok {
+		return lexer.dumpMirrorTokenStackToString()
+	}
+
+	content := lexer.JSONContent.String()
+	frame := lexer.getTopPathFrame()
+	completedKey := ""
+	var tail strings.Builder
+	switch {
+	case !frame.isArray && isObjectKeyPlaceholderSegment(segment):
+		// stopped in key, like `{"fie`, complete key by properity names, required properties first
+		prefix := frame.lastKey(content)
+		completedKey = prefix
+		names := frame.schema.getMissingPropertyNames(frame.keys, prefix, true)
+		if len(names) == 0 {
+			names = frame.schema.getMissingPropertyNames(frame.keys, prefix, false)
+		}
+		if len(names) > 0 {
+			completedKey = names[0]
+			writeJSONStringSuffix(&tail, completedKey, prefix)
+		}
+		tail.WriteString(`":`)
+		tail.WriteString(frame.schema.getPropertySchema(completedKey).placeholder(0))
+	case !frame.isArray && isMirrorSegment(segment, TOKEN_ALPHABET_LOWERCASE_L, TOKEN_ALPHABET_LOWERCASE_L, TOKEN_ALPHABET_LOWERCASE_U, TOKEN_ALPHABET_LOWERCASE_N, TOKEN_COLON):
+		// stopped after key, like `{"field"`
+		tail.WriteByte(TOKEN_COLON_SYMBOL)
+		tail.WriteString(frame.schema.getPropertySchema(frame.lastKey(content)).placeholder(0))
+	case !frame.isArray && isMirrorSegment(segment, TOKEN_ALPHABET_LOWERCASE_L, TOKEN_ALPHABET_LOWERCASE_L, TOKEN_ALPHABET_LOWERCASE_U, TOKEN_ALPHABET_LOWERCASE_N):
+		// stopped after colon, like `{"field":`
+		tail.WriteString(frame.schema.getPropertySchema(frame.lastKey(content)).placeholder(0))
+	case isMirrorSegment(segment, TOKEN_QUOTE):
+		// stopped in string value, like `{"field":"in_pro`, complete string by enum
+		prefix := decodeJSONStringContent(content[frame.valueStart+1:])
+		if candidates := lexer.getCurrentValueSchema().getStringCandidates(prefix); len(candidates) > 0 {
+			writeJSONStringSuffix(&tail, candidates[0], prefix)
+		}
+		tail.WriteByte(TOKEN_QUOTE_SYMBOL)
+	default:
+		dumpMirrorSegmentToString(&tail, segment)
+	}
+
+	// fill required properties and close containers
+	for i := len(lexer.pathFrames) - 1; i >= 0; i-- {
+		frame := &lexer.pathFrames[i]
+		if frame.isArray {
+			tail.WriteByte(TOKEN_RIGHT_BRACKET_SYMBOL)
+			continue
+		}
+		if frame.schema != nil {
+			keys := frame.keys
+			if i == len(lexer.pathFrames)-1 && completedKey != "" {
+				keys = append(keys[:len(keys):len(keys)], completedKey)
+			}
+			frame.schema.resolve().writeRequiredProperties(&tail, keys, frame.members > 0, 0)
+		}
+		tail.WriteByte(TOKEN_RIGHT_BRACE_SYMBOL)
+	}
+	return tail.String()
+}
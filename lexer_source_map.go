@@ -0,0 +1,140 @@
+package streamingjsongo
+
+import (
+	"sort"
+)
+
+// run of JSON content copied from JSON stream, the JSON content from offset content maps to JSON stream from offset stream
+type sourceRun struct {
+	content int
+	stream  int
+}
+
+// map between JSON content and JSON stream appended.
+// JSON content is the JSON stream with a few bytes dropped (like the dangling `-` of `[-]`), so it is runs of JSON stream.
+type sourceMap struct {
+	runs         []sourceRun // runs in order, empty if JSON content is the JSON stream as is
+	mapped       int         // length of JSON content mapped
+	pending      []byte      // JSON stream consumed but not in JSON content yet, like padding content
+	pendingStart int         // offset of pending in JSON stream
+}
+
+// get offset in the appended JSON stream of the byte at given offset in CompleteJSON() output.
+// false if the byte is completed by lexer (the offset is in completed tail), the offset of JSON stream end is returned,
+// which is where the completed tail is inserted. -1 and false if the offset is out of CompleteJSON() output.
+// the offsets are exact for JSON, the whitespace around the bytes out of JSON grammar may be written out of order, like `a ` of ` a`,
+// which is mapped to the nearest offset in JSON stream.
+func (lexer *Lexer) SourceOffset(completedOffset int) (int, bool) {
+	if completedOffset < 0 {
+		return -1, false
+	}
+	if completedOffset >= lexer.JSONContent.Len() {
+		if completedOffset >= lexer.JSONContent.Len()+len(lexer.completeJSONTail()) {
+			return -1, false
+		}
+		return lexer.streamLength, false
+	}
+	return lexer.sourceMap.streamOffset(completedOffset), true
+}
+
+// get span [start, end) of node in CompleteJSON() output, the completed tail is not included
+func (node *Node) Span() (int, int) {
+	return node.start, node.start + len(node.Raw)
+}
+
+// get span [start, end) of node in the appended JSON stream, the whitespace and bytes dropped inside the node are included
+func (lexer *Lexer) SourceSpan(node *Node) (int, int) {
+	start, end := node.Span()
+	if start == end {
+		return lexer.sourceMap.streamOffset(start), lexer.sourceMap.streamOffset(start)
+	}
+	return lexer.sourceMap.streamOffset(start), lexer.sourceMap.streamOffset(end-1) + 1
+}
+
+// get offset in JSON stream of the byte at given offset in JSON content
+func (sourceMap *sourceMap) streamOffset(contentOffset int) int {
+	i := sort.Search(len(sourceMap.runs), func(i int) bool {
+		return sourceMap.runs[i].content > contentOffset
+	})
+	if i == 0 {
+		return contentOffset
+	}
+	run := sourceMap.runs[i-1]
+	return run.stream + contentOffset - run.content
+}
+
+// map JSON content written since last update to given JSON stream consumed, which starts at stream offset consumedStart.
+// padding is the padding content after JSON stream consumed, which is the rest of JSON stream not in JSON content yet.
+func (sourceMap *sourceMap) update(content string, padding []byte, consumed string, consumedStart int) {
+	written := content[sourceMap.mapped:]
+	if len(sourceMap.pending) == 0 {
+		sourceMap.pendingStart = consumedStart
+	}
+	// JSON content is the pending and consumed JSON stream as is in most cases, the bytes are confirmed,
+	// since the lengths can match while the bytes don't, like `} a` written as `}a` with padding ` `
+	pendingLen := len(sourceMap.pending)
+	streamLen := pendingLen + len(consumed)
+	if len(written) <= streamLen && hasPrefixOfConcat(written, sourceMap.pending, consumed) {
+		sourceMap.addRun(sourceMap.mapped, sourceMap.pendingStart)
+		sourceMap.consume(written, len(written), consumed)
+		return
+	}
+	// some bytes are dropped, match JSON content with JSON stream byte by byte.
+	// the whitespace around the bytes out of JSON grammar may be written out of order, like `a ` of ` a`,
+	// the byte not found in the rest of JSON stream is mapped to the current offset, so the map stays in JSON stream consumed
+	j := 0
+	for k := 0; k < len(written); k++ {
+		found := j
+		for found < streamLen && byteOfConcat(sourceMap.pending, consumed, found) != written[k] {
+			found++
+		}
+		if found < streamLen {
+			j = found
+		}
+		sourceMap.addRun(sourceMap.mapped+k, sourceMap.pendingStart+j)
+		if found < streamLen {
+			j++
+		}
+	}
+	sourceMap.consume(written, j, consumed)
+}
+
+// mark written JSON content mapped, and the first n bytes of pending and consumed JSON stream
+func (sourceMap *sourceMap) consume(written string, n int, consumed string) {
+	sourceMap.mapped += len(written)
+	sourceMap.pendingStart += n
+	if n >= len(sourceMap.pending) {
+		consumedStart := n - len(sourceMap.pending)
+		if consumedStart > len(consumed) {
+			consumedStart = len(consumed)
+		}
+		sourceMap.pending = append(sourceMap.pending[:0], consumed[consumedStart:]...)
+		return
+	}
+	sourceMap.pending = append(sourceMap.pending[:0], sourceMap.pending[n:]...)
+	sourceMap.pending = append(sourceMap.pending, consumed...)
+}
+
+// add run starting at given offsets, unless it continues the last run
+func (sourceMap *sourceMap) addRun(content int, stream int) {
+	if sourceMap.streamOffset(content) == stream {
+		return
+	}
+	sourceMap.runs = append(sourceMap.runs, sourceRun{content: content, stream: stream})
+}
+
+// check if s is prefix of a concat b
+func hasPrefixOfConcat(s string, a []byte, b string) bool {
+	if len(s) <= len(a) {
+		return s == string(a[:len(s)])
+	}
+	return s[:len(a)] == string(a) && s[len(a):] == b[:len(s)-len(a)]
+}
+
+// get byte at offset i of a concat b
+func byteOfConcat(a []byte, b string, i int) byte {
+	if i < len(a) {
+		return a[i]
+	}
+	return b[i-len(a)]
+}
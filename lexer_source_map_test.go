@@ -0,0 +1,125 @@
+package streamingjsongo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceOffset(t *testing.T) {
+	documents := []string{
+		`  {"a" : [1 , -2.5e+3, "x你\n\""], "b": {"c": true} } `,
+		`{"a": [-], "b": "c"}`,
+		`{"a":/"b"}`,
+		`[-.5, 1]`,
+	}
+	for _, document := range documents {
+		for _, chunkSize := range []int{1, 3, len(document)} {
+			lexer := NewLexer()
+			for _, chunk := range splitIntoChunks(document, chunkSize) {
+				lexer.AppendString(chunk)
+				// every byte of JSON content maps to the same byte in JSON stream, in order
+				completed := lexer.CompleteJSON()
+				last := -1
+				for i := 0; i < lexer.JSONContent.Len(); i++ {
+					offset, ok := lexer.SourceOffset(i)
+					assert.True(t, ok)
+					assert.Greater(t, offset, last, document)
+					assert.Equal(t, string(completed[i]), string(document[offset]), document)
+					last = offset
+				}
+			}
+		}
+	}
+}
+
+func TestSourceOffset_droppedBytes(t *testing.T) {
+	lexer := NewLexer()
+	lexer.AppendString(`{"a": [-`)
+	lexer.AppendString(`], "b": 1}`)
+	assert.Equal(t, `{"a": [], "b": 1}`, lexer.CompleteJSON())
+	offset, _ := lexer.SourceOffset(7)
+	assert.Equal(t, 8, offset)
+	offset, _ = lexer.SourceOffset(15)
+	assert.Equal(t, 16, offset)
+}
+
+func TestSourceOffset_nothingDropped(t *testing.T) {
+	// the map stays empty while JSON content is the JSON stream as is, the pending JSON stream is the padding content
+	lexer := NewLexer()
+	for _, chunk := range splitIntoChunks(`{"a": [1, "x\u00e9", true], "b": -0.5e-1 }`, 3) {
+		assert.Nil(t, lexer.AppendString(chunk))
+		assert.Equal(t, 0, len(lexer.sourceMap.runs))
+		assert.Equal(t, string(lexer.paddingContent), string(lexer.sourceMap.pending))
+	}
+	lexer.AppendString(`x, 1}`)
+	offset, _ := lexer.SourceOffset(lexer.JSONContent.Len() - 1)
+	assert.Equal(t, 46, offset)
+}
+
+func TestSourceOffset_reorderedBytes(t *testing.T) {
+	// the byte out of JSON grammar is written before the whitespace in front of it, the lengths match but the bytes don't
+	lexer := NewLexer()
+	lexer.AppendString(`f1 a`)
+	assert.Equal(t, "f1a", lexer.JSONContent.String())
+	assert.Equal(t, " ", lexer.PaddingContent.String())
+	offset, _ := lexer.SourceOffset(2)
+	assert.Equal(t, 3, offset)
+
+	// the whitespace not found in the rest of JSON stream stays in JSON stream consumed
+	lexer = NewLexer()
+	lexer.AppendString(`[f1 a]`)
+	assert.Equal(t, "[f1a ]", lexer.JSONContent.String())
+	for i, expected := range []int{0, 1, 2, 4, 5, 5} {
+		offset, _ := lexer.SourceOffset(i)
+		assert.Equal(t, expected, offset)
+	}
+	assert.Equal(t, 6, lexer.sourceMap.pendingStart)
+}
+
+func TestSourceOffset_completedTail(t *testing.T) {
+	lexer := NewLexer()
+	lexer.AppendString(`{"a": tr`)
+	assert.Equal(t, `{"a": true}`, lexer.CompleteJSON())
+	offset, ok := lexer.SourceOffset(7)
+	assert.Equal(t, 7, offset)
+	assert.True(t, ok)
+	for _, completedOffset := range []int{8, 10} {
+		offset, ok = lexer.SourceOffset(completedOffset)
+		assert.Equal(t, 8, offset)
+		assert.False(t, ok)
+	}
+	for _, completedOffset := range []int{-1, 11} {
+		offset, ok = lexer.SourceOffset(completedOffset)
+		assert.Equal(t, -1, offset)
+		assert.False(t, ok)
+	}
+}
+
+func TestSourceSpan(t *testing.T) {
+	document := `{"a": [-], "steps": [ {"title": "plan"}, 12 , "wri`
+	lexer := NewLexer()
+	for _, chunk := range splitIntoChunks(document, 4) {
+		lexer.AppendString(chunk)
+	}
+	steps := lexer.Root().Children[1]
+	testCases := []struct {
+		node     *Node
+		source   string
+		spanFrom int // offset in completed JSON, the dropped `-` is not counted
+	}{
+		{steps, `[ {"title": "plan"}, 12 , "wri`, 19},
+		{steps.Children[0], `{"title": "plan"}`, 21},
+		{steps.Children[0].Children[0], `"plan"`, 31},
+		{steps.Children[1], `12`, 40},
+		{steps.Children[2], `"wri`, 45},
+	}
+	completed := lexer.CompleteJSON()
+	for _, testCase := range testCases {
+		start, end := testCase.node.Span()
+		assert.Equal(t, testCase.spanFrom, start)
+		assert.Equal(t, testCase.node.Raw, completed[start:end])
+		start, end = lexer.SourceSpan(testCase.node)
+		assert.Equal(t, testCase.source, document[start:end])
+	}
+}
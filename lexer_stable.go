@@ -0,0 +1,22 @@
+package streamingjsongo
+
+// completed JSON separated by stability
+type Completion struct {
+	Stable   string // prefix of completed JSON which will never change again as the JSON stream continues
+	Volatile string // tail of completed JSON built by mirror stack, like placeholder `null`, rest of partial literal, `0` for `-`
+}
+
+// get length of the stable prefix in completed JSON, renderers can append-only the stable part.
+// the JSON content is only appended, and everything auto-completed comes from the mirror stack,
+// so all of the JSON content is stable. in bounded-memory mode it is the length of JSON content kept, add FlushedLength() for the whole.
+func (lexer *Lexer) StableLength() int {
+	return lexer.JSONContent.Len()
+}
+
+// complete the incomplete JSON string and separate it into stable part and volatile part
+func (lexer *Lexer) Completion() Completion {
+	return Completion{
+		Stable:   lexer.JSONContent.String(),
+		Volatile: lexer.completeJSONTail(),
+	}
+}
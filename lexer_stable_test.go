@@ -0,0 +1,47 @@
+package streamingjsongo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletion_base(t *testing.T) {
+	streamingJSONCase := map[string]Completion{
+		`{"a":`:        {Stable: `{"a":`, Volatile: `null}`},
+		`{"a":tr`:      {Stable: `{"a":tr`, Volatile: `ue}`},
+		`{"a":12.`:     {Stable: `{"a":12`, Volatile: `}`},
+		`{"a":[1, `:    {Stable: `{"a":[1`, Volatile: `]}`},
+		`{"a":"\u00`:   {Stable: `{"a":"`, Volatile: `"}`},
+		`{"a":"b"}`:    {Stable: `{"a":"b"}`, Volatile: ``},
+		`[{"a":-`:      {Stable: `[{"a":`, Volatile: `0}]`},
+		`[{"a":-1.2e`:  {Stable: `[{"a":-1.2`, Volatile: `}]`},
+		`[{"a":-1.2e1`: {Stable: `[{"a":-1.2e1`, Volatile: `}]`},
+	}
+	for testCase, expect := range streamingJSONCase {
+		lexer := NewLexer()
+		assert.Nil(t, lexer.AppendString(testCase))
+		completion := lexer.Completion()
+		assert.Equal(t, expect, completion, "unexpected completion for `%s`", testCase)
+		assert.Equal(t, len(expect.Stable), lexer.StableLength())
+		assert.Equal(t, lexer.CompleteJSON(), completion.Stable+completion.Volatile)
+	}
+}
+
+func TestCompletion_stablePrefix(t *testing.T) {
+	streamingJSONContent := `{"string": "这是一个字符串\"A", "integer": -42, "float": 3.14159e-2, "boolean_true": true, "boolean_false": false, "null": null, "object": {"empty_object": {}, "nested_object": {"nested_key": {"sub_nested_key": "sub_nested_value"}}}, "array":["string in array", 123, 45.67, true, false, null, {"object_in_array": "object_value"},["nested_array"]]}`
+	lexer := NewLexer()
+	var stables []string
+	for _, char := range streamingJSONContent {
+		assert.Nil(t, lexer.AppendString(string(char)))
+		completed := lexer.CompleteJSON()
+		for _, stable := range stables {
+			if !assert.True(t, strings.HasPrefix(completed, stable), "stable part `%s` changed in `%s`", stable, completed) {
+				return
+			}
+		}
+		stables = append(stables, lexer.Completion().Stable)
+	}
+	assert.Equal(t, streamingJSONContent, lexer.CompleteJSON())
+}
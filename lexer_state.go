@@ -0,0 +1,506 @@
+package streamingjsongo
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+// version of serialized lexer state, increased when the state format changes
+const LEXER_STATE_VERSION = 1
+
+// header of lexer state in binary form, followed by version byte
+const LEXER_STATE_BINARY_MAGIC = "SJGO"
+
+// serialized lexer state, the caches (formatted content, value tree) are not included and rebuilt from JSON content.
+// the JSON stream may stop in the middle of UTF-8 character, so the contents are split into string and the tail in bytes,
+// which starts from the first invalid UTF-8 byte, see splitStateText().
+type lexerState struct {
+	Version             int                   `json:"version"`
+	Schema              bool                  `json:"schema"` // schema attached, the schema itself is not serialized
+	Content             string                `json:"jsonContent"`
+	ContentTail         []byte                `json:"jsonContentTail,omitempty"`
+	Padding             string                `json:"paddingContent"`
+	PaddingTail         []byte                `json:"paddingContentTail,omitempty"`
+	Segment             string                `json:"jsonSegment"`
+	SegmentTail         []byte                `json:"jsonSegmentTail,omitempty"`
+	MirrorTokenStack    []int                 `json:"mirrorTokenStack"`
+	Tokens              []int                 `json:"tokens"`
+	State               int                   `json:"state"`
+	FinalizedValues     int                   `json:"finalizedValues"`
+	Number              numberStateState      `json:"number"`
+	PathFrames          []pathFrameState      `json:"pathFrames"`
+	LastClosedPathFrame pathFrameState        `json:"lastClosedPathFrame"`
+	RootStarted         bool                  `json:"rootStarted"`
+	PatchBase           patchBaseState        `json:"patchBase"`
+	Validation          schemaValidationState `json:"validation"`
+	Strict              grammarCheckerState   `json:"strict"`
+	StreamLength        int                   `json:"streamLength"`
+	SourceMap           sourceMapState        `json:"sourceMap"`
+	Flushed             int                   `json:"flushed"` // length of JSON content flushed in bounded-memory mode
+}
+
+// serialized numberState
+type numberStateState struct {
+	Step int `json:"step"`
+}
+
+// serialized pathFrame, the schema of frame is resolved from the schema of lexer
+type pathFrameState struct {
+	IsArray     bool     `json:"isArray"`
+	Start       int      `json:"start"`
+	Members     int      `json:"members"`
+	MemberStart int      `json:"memberStart"`
+	KeyStart    int      `json:"keyStart"`
+	KeyEnd      int      `json:"keyEnd"`
+	ValueStart  int      `json:"valueStart"`
+	Keys        []string `json:"keys"`
+	Key         string   `json:"key"`
+	KeyKept     bool     `json:"keyKept"`
+}
+
+// serialized patchBase
+type patchBaseState struct {
+	RootStarted bool             `json:"rootStarted"`
+	PathFrames  []pathFrameState `json:"pathFrames"`
+	ContentLen  int              `json:"contentLen"`
+	Tail        string           `json:"tail"`
+}
+
+// serialized schemaValidation
+type schemaValidationState struct {
+	Enabled       bool             `json:"enabled"`
+	Violation     *SchemaViolation `json:"violation"`
+	StringStart   int              `json:"stringStart"`
+	StringScanned int              `json:"stringScanned"`
+	StringLength  int              `json:"stringLength"`
+}
+
+// serialized grammarChecker
+type grammarCheckerState struct {
+	Enabled    bool         `json:"enabled"`
+	Err        *SyntaxError `json:"err"`
+	Step       int          `json:"step"`
+	Containers []int        `json:"containers"`
+	InKey      bool         `json:"inKey"`
+	Literal    string       `json:"literal"`
+	HexDigits  int          `json:"hexDigits"`
+	UTF8Bytes  int          `json:"utf8Bytes"`
+	UTF8Lower  byte         `json:"utf8Lower"`
+	UTF8Upper  byte         `json:"utf8Upper"`
+}
+
+// serialized sourceMap
+type sourceMapState struct {
+	Runs         []sourceRunState `json:"runs"`
+	Mapped       int              `json:"mapped"`
+	PendingText  string           `json:"pending"`
+	PendingTail  []byte           `json:"pendingTail,omitempty"`
+	PendingStart int              `json:"pendingStart"`
+}
+
+// serialized sourceRun
+type sourceRunState struct {
+	Content int `json:"content"`
+	Stream  int `json:"stream"`
+}
+
+// serialize lexer state into binary form, `SJGO` and version byte followed by the state in gob.
+// the schema and the writer of bounded-memory mode are not serialized, unmarshal the state into lexer created with the same ones.
+func (lexer *Lexer) MarshalBinary() ([]byte, error) {
+	var data bytes.Buffer
+	data.WriteString(LEXER_STATE_BINARY_MAGIC)
+	data.WriteByte(LEXER_STATE_VERSION)
+	if err := gob.NewEncoder(&data).Encode(lexer.dumpState()); err != nil {
+		return nil, err
+	}
+	return data.Bytes(), nil
+}
+
+// restore lexer state from binary form, the JSON stream can be appended and completed as the serialized lexer.
+// the lexer must be created with the same schema as the serialized lexer, like NewLexerWithSchema(schema).
+func (lexer *Lexer) UnmarshalBinary(data []byte) error {
+	if !bytes.HasPrefix(data, []byte(LEXER_STATE_BINARY_MAGIC)) || len(data) == len(LEXER_STATE_BINARY_MAGIC) {
+		return fmt.Errorf("invalid lexer state header")
+	}
+	version := int(data[len(LEXER_STATE_BINARY_MAGIC)])
+	if version != LEXER_STATE_VERSION {
+		return fmt.Errorf("unsupported lexer state version %d", version)
+	}
+	var state lexerState
+	if err := gob.NewDecoder(bytes.NewReader(data[len(LEXER_STATE_BINARY_MAGIC)+1:])).Decode(&state); err != nil {
+		return fmt.Errorf("invalid lexer state: %s", err)
+	}
+	return lexer.restoreState(&state)
+}
+
+// serialize lexer state into JSON form, the schema is not serialized
+func (lexer *Lexer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(lexer.dumpState())
+}
+
+// restore lexer state from JSON form, the lexer must be created with the same schema as the serialized lexer
+func (lexer *Lexer) UnmarshalJSON(data []byte) error {
+	var state lexerState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("invalid lexer state: %s", err)
+	}
+	if state.Version != LEXER_STATE_VERSION {
+		return fmt.Errorf("unsupported lexer state version %d", state.Version)
+	}
+	return lexer.restoreState(&state)
+}
+
+// dump lexer state for serialization
+func (lexer *Lexer) dumpState() *lexerState {
+	state := &lexerState{
+		Version:             LEXER_STATE_VERSION,
+		Schema:              lexer.schema != nil,
+		MirrorTokenStack:    lexer.MirrorTokenStack,
+		Tokens:              lexer.TokenStack,
+		State:               lexer.state,
+		FinalizedValues:     lexer.finalizedValues,
+		Number:              numberStateState{Step: lexer.number.step},
+		PathFrames:          dumpPathFrames(lexer.pathFrames),
+		LastClosedPathFrame: dumpPathFrame(lexer.lastClosedPathFrame),
+		RootStarted:         lexer.rootStarted,
+		PatchBase: patchBaseState{
+			RootStarted: lexer.patchBase.rootStarted,
+			PathFrames:  dumpPathFrames(lexer.patchBase.pathFrames),
+			ContentLen:  lexer.patchBase.contentLen,
+			Tail:        lexer.patchBase.tail,
+		},
+		Validation: schemaValidationState{
+			Enabled:       lexer.validation.enabled,
+			Violation:     lexer.validation.violation,
+			StringStart:   lexer.validation.stringStart,
+			StringScanned: lexer.validation.stringScanned,
+			StringLength:  lexer.validation.stringLength,
+		},
+		Strict: grammarCheckerState{
+			Enabled:    lexer.strict.enabled,
+			Err:        lexer.strict.err,
+			Step:       lexer.strict.step,
+			Containers: lexer.strict.containers,
+			InKey:      lexer.strict.inKey,
+			Literal:    lexer.strict.literal,
+			HexDigits:  lexer.strict.hexDigits,
+			UTF8Bytes:  lexer.strict.utf8Bytes,
+			UTF8Lower:  lexer.strict.utf8Lower,
+			UTF8Upper:  lexer.strict.utf8Upper,
+		},
+		StreamLength: lexer.streamLength,
+		Flushed:      lexer.flush.flushed,
+		SourceMap: sourceMapState{
+			Mapped:       lexer.sourceMap.mapped,
+			PendingStart: lexer.sourceMap.pendingStart,
+		},
+	}
+	state.Content, state.ContentTail = splitStateText(lexer.JSONContent.String())
+	state.Padding, state.PaddingTail = splitStateText(string(lexer.paddingContent))
+	state.Segment, state.SegmentTail = splitStateText(lexer.JSONSegment)
+	state.SourceMap.PendingText, state.SourceMap.PendingTail = splitStateText(string(lexer.sourceMap.pending))
+	for _, run := range lexer.sourceMap.runs {
+		state.SourceMap.Runs = append(state.SourceMap.Runs, sourceRunState{Content: run.content, Stream: run.stream})
+	}
+	return state
+}
+
+// restore lexer from state, the schema and the writer of bounded-memory mode of lexer are kept.
+// the lexer is left as it was if the state is invalid
+func (lexer *Lexer) restoreState(state *lexerState) error {
+	if state.Schema != (lexer.schema != nil) {
+		if state.Schema {
+			return fmt.Errorf("lexer state has schema attached, restore it into lexer with the same schema")
+		}
+		return fmt.Errorf("lexer state has no schema attached, restore it into lexer without schema")
+	}
+	if err := validateState(state); err != nil {
+		return fmt.Errorf("invalid lexer state: %s", err)
+	}
+	*lexer = Lexer{
+		JSONSegment:         string(joinStateText(state.Segment, state.SegmentTail)),
+		TokenStack:          state.Tokens,
+		MirrorTokenStack:    state.MirrorTokenStack,
+		paddingContent:      joinStateText(state.Padding, state.PaddingTail),
+		state:               state.State,
+		finalizedValues:     state.FinalizedValues,
+		number:              numberState{step: state.Number.Step},
+		lastClosedPathFrame: restorePathFrame(state.LastClosedPathFrame),
+		rootStarted:         state.RootStarted,
+		patchBase: patchBase{
+			rootStarted: state.PatchBase.RootStarted,
+			pathFrames:  restorePathFrames(state.PatchBase.PathFrames),
+			contentLen:  state.PatchBase.ContentLen,
+			tail:        state.PatchBase.Tail,
+		},
+		schema: lexer.schema,
+		validation: schemaValidation{
+			enabled:       state.Validation.Enabled,
+			violation:     state.Validation.Violation,
+			stringStart:   state.Validation.StringStart,
+			stringScanned: state.Validation.StringScanned,
+			stringLength:  state.Validation.StringLength,
+			enumStart:     -1,
+		},
+		strict: grammarChecker{
+			enabled:    state.Strict.Enabled,
+			err:        state.Strict.Err,
+			step:       state.Strict.Step,
+			containers: state.Strict.Containers,
+			inKey:      state.Strict.InKey,
+			literal:    state.Strict.Literal,
+			hexDigits:  state.Strict.HexDigits,
+			utf8Bytes:  state.Strict.UTF8Bytes,
+			utf8Lower:  state.Strict.UTF8Lower,
+			utf8Upper:  state.Strict.UTF8Upper,
+		},
+		streamLength: state.StreamLength,
+		flush:        contentFlush{writer: lexer.flush.writer, flushed: state.Flushed},
+		sourceMap: sourceMap{
+			mapped:       state.SourceMap.Mapped,
+			pending:      joinStateText(state.SourceMap.PendingText, state.SourceMap.PendingTail),
+			pendingStart: state.SourceMap.PendingStart,
+		},
+	}
+	lexer.JSONContent.WriteString(state.Content)
+	lexer.JSONContent.Write(state.ContentTail)
+	for _, run := range state.SourceMap.Runs {
+		lexer.sourceMap.runs = append(lexer.sourceMap.runs, sourceRun{content: run.Content, stream: run.Stream})
+	}
+
+	// resolve schema of container frames from root, like they are opened
+	frames := restorePathFrames(state.PathFrames)
+	if lexer.schema != nil {
+		for i := range frames {
+			lexer.pathFrames = frames[:i]
+			frames[i].schema = lexer.getCurrentValueSchema()
+		}
+	}
+	lexer.pathFrames = frames
+	lexer.updatePaddingContent()
+	return nil
+}
+
+// check the lexer state is one the lexer can be in, so the truncated or tampered state is refused instead of breaking the lexer later
+func validateState(state *lexerState) error {
+	contentLen := len(state.Content) + len(state.ContentTail)
+	if state.State < 0 || state.State >= STREAM_STATE_COUNT {
+		return fmt.Errorf("unknown stream state %d", state.State)
+	}
+	if state.StreamLength < 0 || state.FinalizedValues < 0 || state.Flushed < 0 {
+		return fmt.Errorf("negative length or count")
+	}
+	for _, token := range state.Tokens {
+		if token < TOKEN_EOF || token > TOKEN_OTHERS {
+			return fmt.Errorf("unknown token %d in token stack", token)
+		}
+	}
+	if err := validateMirrorTokenStack(state); err != nil {
+		return err
+	}
+	if state.Number.Step != GRAMMAR_STEP_VALUE && (state.Number.Step < GRAMMAR_STEP_NUMBER_SIGN || state.Number.Step > GRAMMAR_STEP_NUMBER_EXPONENT_DIGITS) {
+		return fmt.Errorf("unknown number step %d", state.Number.Step)
+	}
+	if state.Strict.Step < GRAMMAR_STEP_VALUE || state.Strict.Step > GRAMMAR_STEP_END {
+		return fmt.Errorf("unknown grammar step %d", state.Strict.Step)
+	}
+	for _, token := range state.Strict.Containers {
+		if token != TOKEN_LEFT_BRACE && token != TOKEN_LEFT_BRACKET {
+			return fmt.Errorf("unknown container token %d in grammar checker", token)
+		}
+	}
+
+	// the offsets in JSON content
+	frames := append([]pathFrameState{state.LastClosedPathFrame}, state.PathFrames...)
+	frames = append(frames, state.PatchBase.PathFrames...)
+	for _, frame := range frames {
+		for _, offset := range []int{frame.Start, frame.MemberStart, frame.KeyStart, frame.KeyEnd, frame.ValueStart} {
+			// the offsets before the content kept are negative in bounded-memory mode, -1 if not set
+			if offset < -state.Flushed-1 || offset > contentLen {
+				return fmt.Errorf("container frame offset %d out of JSON content", offset)
+			}
+		}
+	}
+	// the last key of object is read from JSON content unless it is kept in frame
+	for _, frame := range state.PathFrames {
+		if !frame.IsArray && frame.Members > 0 && !frame.KeyKept && (frame.KeyStart < 0 || (frame.KeyEnd >= 0 && frame.KeyStart > frame.KeyEnd)) {
+			return fmt.Errorf("properity key offset %d out of JSON content", frame.KeyStart)
+		}
+	}
+	if state.PatchBase.ContentLen < 0 || state.PatchBase.ContentLen > contentLen {
+		return fmt.Errorf("patch base length %d out of JSON content", state.PatchBase.ContentLen)
+	}
+	if validation := &state.Validation; validation.Enabled && validation.StringStart >= 0 && (validation.StringStart >= validation.StringScanned || validation.StringScanned > contentLen) {
+		return fmt.Errorf("validated string offset out of JSON content")
+	}
+
+	// the source map, the runs are in order of both offsets, the later one of runs at the same offset is used
+	sourceMap := &state.SourceMap
+	if sourceMap.Mapped < 0 || sourceMap.Mapped > contentLen {
+		return fmt.Errorf("source map length %d out of JSON content", sourceMap.Mapped)
+	}
+	if sourceMap.PendingStart < 0 || sourceMap.PendingStart+len(sourceMap.PendingText)+len(sourceMap.PendingTail) > state.StreamLength {
+		return fmt.Errorf("source map pending offset %d out of JSON stream", sourceMap.PendingStart)
+	}
+	previous := sourceRunState{}
+	for _, run := range sourceMap.Runs {
+		if run.Content < previous.Content || run.Content > sourceMap.Mapped || run.Stream < previous.Stream || run.Stream > state.StreamLength {
+			return fmt.Errorf("source map run out of range or order")
+		}
+		previous = run
+	}
+	return nil
+}
+
+// check the mirror token stack of lexer state matches the stream state and container frames
+func validateMirrorTokenStack(state *lexerState) error {
+	mirror := state.MirrorTokenStack
+	var closers []int
+	for _, token := range mirror {
+		switch token {
+		case TOKEN_RIGHT_BRACE, TOKEN_RIGHT_BRACKET:
+			closers = append(closers, token)
+		case TOKEN_QUOTE, TOKEN_COLON, TOKEN_NUMBER_0, TOKEN_ALPHABET_LOWERCASE_N:
+		default:
+			if !isLiteralLetterToken(token) {
+				return fmt.Errorf("unknown token %d in mirror token stack", token)
+			}
+		}
+	}
+	// the closers are the containers on path, the frames only track the first root value,
+	// and the frame is closed by the mismatched closer while its closer stays on mirror stack, like `[[}`
+	if len(state.PathFrames) > len(closers) {
+		return fmt.Errorf("mirror token stack does not match container frames")
+	}
+	for i, frame := range state.PathFrames {
+		if frame.IsArray != (closers[i] == TOKEN_RIGHT_BRACKET) {
+			return fmt.Errorf("mirror token stack does not match container frames")
+		}
+	}
+
+	// the stream state needs the innermost container and the placeholder on mirror stack top
+	innermost := TOKEN_EOF
+	if len(closers) > 0 {
+		innermost = closers[len(closers)-1]
+	}
+	var placeholder []int
+	switch state.State {
+	case STREAM_STATE_VALUE:
+		innermost = TOKEN_EOF
+	case STREAM_STATE_ARRAY_VALUE:
+		innermost = TOKEN_RIGHT_BRACKET
+	case STREAM_STATE_ARRAY_VALUE_END:
+		innermost = TOKEN_RIGHT_BRACKET
+	case STREAM_STATE_OBJECT_KEY:
+		innermost = TOKEN_RIGHT_BRACE
+	case STREAM_STATE_KEY, STREAM_STATE_KEY_ESCAPE, STREAM_STATE_KEY_UNICODE_ESCAPE:
+		innermost, placeholder = TOKEN_RIGHT_BRACE, MIRROR_PLACEHOLDER_OBJECT_KEY
+	case STREAM_STATE_COLON:
+		innermost, placeholder = TOKEN_RIGHT_BRACE, MIRROR_PLACEHOLDER_OBJECT_KEY[:len(MIRROR_PLACEHOLDER_OBJECT_KEY)-1]
+	case STREAM_STATE_OBJECT_VALUE:
+		innermost, placeholder = TOKEN_RIGHT_BRACE, MIRROR_PLACEHOLDER_OBJECT_KEY[:MIRROR_PLACEHOLDER_OBJECT_VALUE_LENGTH]
+	case STREAM_STATE_OBJECT_VALUE_END:
+		innermost = TOKEN_RIGHT_BRACE
+	case STREAM_STATE_STRING, STREAM_STATE_STRING_ESCAPE, STREAM_STATE_STRING_UNICODE_ESCAPE:
+		placeholder = []int{TOKEN_QUOTE}
+	}
+	if (len(closers) == 0 && innermost != TOKEN_EOF) || (len(closers) > 0 && innermost != closers[len(closers)-1]) {
+		return fmt.Errorf("mirror token stack does not match stream state %d", state.State)
+	}
+	if len(mirror) < len(placeholder) || !isMirrorSegment(mirror[len(mirror)-len(placeholder):], placeholder...) {
+		return fmt.Errorf("mirror token stack does not match stream state %d", state.State)
+	}
+	// the rest of literal is on mirror stack top
+	if state.State == STREAM_STATE_LITERAL {
+		if len(mirror) == 0 || !isLiteralLetterToken(mirror[len(mirror)-1]) {
+			return fmt.Errorf("mirror token stack does not match stream state %d", state.State)
+		}
+	}
+	return nil
+}
+
+// token is letter of the rest of literal on mirror stack, like `u`, `e` of `true`
+func isLiteralLetterToken(token int) bool {
+	switch token {
+	case TOKEN_ALPHABET_LOWERCASE_A, TOKEN_ALPHABET_LOWERCASE_E, TOKEN_ALPHABET_LOWERCASE_L, TOKEN_ALPHABET_LOWERCASE_R, TOKEN_ALPHABET_LOWERCASE_S, TOKEN_ALPHABET_LOWERCASE_U:
+		return true
+	}
+	return false
+}
+
+// split text of lexer state at the first invalid UTF-8 byte, which is the character split by the end of JSON stream in most cases.
+// the text is string in JSON form, and the tail is in bytes since it can't be in JSON string.
+func splitStateText(s string) (string, []byte) {
+	if utf8.ValidString(s) {
+		return s, nil
+	}
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			return s[:i], []byte(s[i:])
+		}
+		i += size
+	}
+	return s, nil
+}
+
+// join text of lexer state and the tail in bytes
+func joinStateText(s string, tail []byte) []byte {
+	if s == "" && tail == nil {
+		return nil
+	}
+	return append([]byte(s), tail...)
+}
+
+// dump container frames for serialization
+func dumpPathFrames(frames []pathFrame) []pathFrameState {
+	var states []pathFrameState
+	for _, frame := range frames {
+		states = append(states, dumpPathFrame(frame))
+	}
+	return states
+}
+
+// dump container frame for serialization
+func dumpPathFrame(frame pathFrame) pathFrameState {
+	return pathFrameState{
+		IsArray:     frame.isArray,
+		Start:       frame.start,
+		Members:     frame.members,
+		MemberStart: frame.memberStart,
+		KeyStart:    frame.keyStart,
+		KeyEnd:      frame.keyEnd,
+		ValueStart:  frame.valueStart,
+		Keys:        frame.keys,
+		Key:         frame.key,
+		KeyKept:     frame.keyKept,
+	}
+}
+
+// restore container frames from serialized states, without schema
+func restorePathFrames(states []pathFrameState) []pathFrame {
+	var frames []pathFrame
+	for _, state := range states {
+		frames = append(frames, restorePathFrame(state))
+	}
+	return frames
+}
+
+// restore container frame from serialized state, without schema
+func restorePathFrame(state pathFrameState) pathFrame {
+	return pathFrame{
+		isArray:     state.IsArray,
+		start:       state.Start,
+		members:     state.Members,
+		memberStart: state.MemberStart,
+		keyStart:    state.KeyStart,
+		keyEnd:      state.KeyEnd,
+		valueStart:  state.ValueStart,
+		keys:        state.Keys,
+		key:         state.Key,
+		keyKept:     state.KeyKept,
+	}
+}
@@ -0,0 +1,181 @@
+package streamingjsongo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// serialize lexer and restore it into the new lexer, by binary form or JSON form
+func resumeLexer(t *testing.T, lexer *Lexer, resumed *Lexer, binary bool) {
+	if binary {
+		data, err := lexer.MarshalBinary()
+		assert.Nil(t, err)
+		assert.Nil(t, resumed.UnmarshalBinary(data))
+		return
+	}
+	data, err := json.Marshal(lexer)
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(data, resumed))
+}
+
+func TestLexer_MarshalBinary(t *testing.T) {
+	schema, err := NewSchema([]byte(TEST_SCHEMA))
+	assert.Nil(t, err)
+	validationSchema, err := NewSchema([]byte(TEST_VALIDATION_SCHEMA))
+	assert.Nil(t, err)
+	testCases := []struct {
+		name     string
+		newLexer func() *Lexer
+		document string
+	}{
+		{"lexer", NewLexer, `{"a你": [1, -2.5e+3, -0.0e1, -0, "x\"y", true, {"b": null}], "c": "😀"}`},
+		{"strict", NewStrictLexer, `{"a": [1, -2.5e+3, "x\"y", true, {"b": null}], "c": "😀"}`},
+		{"schema", func() *Lexer { return NewLexerWithSchema(schema) }, `{"owner": {"id": 1}, "tags": ["a", "b"], "na`},
+		{"validation", func() *Lexer { return NewLexerWithSchemaValidation(validationSchema) }, `{"name": "ab", "owner": {"id": 1}, "status": "in_`},
+	}
+	for _, testCase := range testCases {
+		for _, binary := range []bool{true, false} {
+			// serialize at every byte, the resumed lexer continues identically
+			for i := 0; i <= len(testCase.document); i++ {
+				lexer := testCase.newLexer()
+				assert.Nil(t, lexer.AppendString(testCase.document[:i]))
+				lexer.CompletionPatch()
+				resumed := testCase.newLexer()
+				resumeLexer(t, lexer, resumed, binary)
+				assert.Equal(t, lexer.CompleteJSON(), resumed.CompleteJSON(), "%s: %s", testCase.name, testCase.document[:i])
+				assert.Equal(t, lexer.DumpTokenStack(), resumed.DumpTokenStack())
+				assert.Equal(t, lexer.PaddingContent.String(), resumed.PaddingContent.String())
+
+				for _, chunk := range splitIntoChunks(testCase.document[i:], 3) {
+					assert.Equal(t, lexer.AppendString(chunk), resumed.AppendString(chunk))
+					assert.Equal(t, lexer.CompleteJSON(), resumed.CompleteJSON(), "%s: %s", testCase.name, testCase.document[:i])
+					patch, err := lexer.CompletionPatch()
+					resumedPatch, resumedErr := resumed.CompletionPatch()
+					assert.Equal(t, patch, resumedPatch)
+					assert.Equal(t, err, resumedErr)
+				}
+				assert.Equal(t, lexer.Completion(), resumed.Completion())
+				assert.Equal(t, lexer.Finish(), resumed.Finish())
+				sourceOffset, _ := lexer.SourceOffset(lexer.JSONContent.Len() - 1)
+				resumedSourceOffset, _ := resumed.SourceOffset(lexer.JSONContent.Len() - 1)
+				assert.Equal(t, sourceOffset, resumedSourceOffset)
+			}
+		}
+	}
+}
+
+func TestLexer_MarshalBinary_error(t *testing.T) {
+	lexer := NewStrictLexer()
+	assert.NotNil(t, lexer.AppendString(`[1x`))
+	data, err := lexer.MarshalBinary()
+	assert.Nil(t, err)
+
+	// the syntax error is sticky after resumed
+	resumed := NewLexer()
+	assert.Nil(t, resumed.UnmarshalBinary(data))
+	assert.Equal(t, &SyntaxError{Offset: 2, Message: `invalid character "x" after value`}, resumed.AppendString(`]`))
+
+	// the invalid state
+	assert.Equal(t, "invalid lexer state header", resumed.UnmarshalBinary([]byte(`SJGO`)).Error())
+	assert.Equal(t, "unsupported lexer state version 9", resumed.UnmarshalBinary([]byte("SJGO\x09")).Error())
+	assert.Equal(t, "unsupported lexer state version 0", resumed.UnmarshalJSON([]byte(`{}`)).Error())
+
+	// the schema must be the same
+	schema, err := NewSchema([]byte(TEST_SCHEMA))
+	assert.Nil(t, err)
+	assert.Equal(t, "lexer state has no schema attached, restore it into lexer without schema", NewLexerWithSchema(schema).UnmarshalBinary(data).Error())
+	data, err = NewLexerWithSchema(schema).MarshalBinary()
+	assert.Nil(t, err)
+	assert.Equal(t, "lexer state has schema attached, restore it into lexer with the same schema", NewLexer().UnmarshalBinary(data).Error())
+}
+
+func TestLexer_MarshalJSON_contents(t *testing.T) {
+	// the contents are strings in JSON form, the UTF-8 character split by the end of JSON stream is in the tail
+	lexer := NewLexer()
+	assert.Nil(t, lexer.AppendString("{\"a\": \"x😀"[:10]))
+	data, err := json.Marshal(lexer)
+	assert.Nil(t, err)
+	var state map[string]interface{}
+	assert.Nil(t, json.Unmarshal(data, &state))
+	assert.Equal(t, `{"a": "x`, state["jsonContent"])
+	assert.Equal(t, "8J8=", state["jsonContentTail"])
+	assert.Equal(t, `{"a": "x`, state["jsonSegment"])
+	assert.Equal(t, "8J8=", state["jsonSegmentTail"])
+	assert.Equal(t, "", state["paddingContent"])
+	assert.Nil(t, state["paddingContentTail"])
+
+	resumed := NewLexer()
+	assert.Nil(t, json.Unmarshal(data, resumed))
+	assert.Nil(t, resumed.AppendString("\x98\x80\"}"))
+	assert.Equal(t, "{\"a\": \"x😀\"}", resumed.CompleteJSON())
+}
+
+func TestLexer_UnmarshalBinary_truncated(t *testing.T) {
+	lexer := NewLexer()
+	assert.Nil(t, lexer.AppendString(`{"a": [1, -2.5e+3, "x\"y", tr`))
+	data, err := lexer.MarshalBinary()
+	assert.Nil(t, err)
+	jsonData, err := json.Marshal(lexer)
+	assert.Nil(t, err)
+
+	// the truncated state is refused, and the lexer still works
+	for i := 0; i < len(data); i++ {
+		resumed := NewLexer()
+		assert.NotNil(t, resumed.UnmarshalBinary(data[:i]), i)
+		assert.Nil(t, resumed.AppendString(`[1`))
+	}
+	for i := 0; i < len(jsonData); i++ {
+		resumed := NewLexer()
+		assert.NotNil(t, resumed.UnmarshalJSON(jsonData[:i]), i)
+	}
+}
+
+func TestLexer_UnmarshalJSON_tampered(t *testing.T) {
+	lexer := NewLexer()
+	assert.Nil(t, lexer.AppendString(`{"a": [1, -`))
+	assert.Nil(t, lexer.AppendString(`], "b": "x`))
+	data, err := json.Marshal(lexer)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		field    string
+		value    interface{}
+		expected string
+	}{
+		{"state", 99, "invalid lexer state: unknown stream state 99"},
+		{"state", -1, "invalid lexer state: unknown stream state -1"},
+		{"state", STREAM_STATE_KEY, "invalid lexer state: mirror token stack does not match stream state 3"},
+		{"state", STREAM_STATE_ARRAY_VALUE_END, "invalid lexer state: mirror token stack does not match stream state 13"},
+		{"tokens", []int{999}, "invalid lexer state: unknown token 999 in token stack"},
+		{"mirrorTokenStack", []int{TOKEN_RIGHT_BRACE, TOKEN_LEFT_BRACE}, "invalid lexer state: unknown token 4 in mirror token stack"},
+		{"mirrorTokenStack", []int{TOKEN_RIGHT_BRACKET, TOKEN_QUOTE}, "invalid lexer state: mirror token stack does not match container frames"},
+		{"mirrorTokenStack", []int{TOKEN_RIGHT_BRACE}, "invalid lexer state: mirror token stack does not match stream state 8"},
+		{"number", map[string]interface{}{"step": 99}, "invalid lexer state: unknown number step 99"},
+		{"pathFrames", []map[string]interface{}{{"start": 99}}, "invalid lexer state: container frame offset 99 out of JSON content"},
+		{"pathFrames", []map[string]interface{}{{"members": 1, "keyStart": 15, "keyEnd": 14}}, "invalid lexer state: properity key offset 15 out of JSON content"},
+		{"patchBase", map[string]interface{}{"contentLen": 99}, "invalid lexer state: patch base length 99 out of JSON content"},
+		{"sourceMap", map[string]interface{}{"mapped": 99}, "invalid lexer state: source map length 99 out of JSON content"},
+		{"sourceMap", map[string]interface{}{"mapped": 20, "pendingStart": 99}, "invalid lexer state: source map pending offset 99 out of JSON stream"},
+		{"sourceMap", map[string]interface{}{"mapped": 20, "runs": []map[string]int{{"content": 10, "stream": 11}, {"content": 5, "stream": 12}}}, "invalid lexer state: source map run out of range or order"},
+		{"sourceMap", map[string]interface{}{"mapped": 20, "runs": []map[string]int{{"content": 10, "stream": 99}}}, "invalid lexer state: source map run out of range or order"},
+		{"streamLength", -1, "invalid lexer state: negative length or count"},
+	}
+	for _, testCase := range testCases {
+		var state map[string]interface{}
+		assert.Nil(t, json.Unmarshal(data, &state))
+		state[testCase.field] = testCase.value
+		tampered, err := json.Marshal(state)
+		assert.Nil(t, err)
+
+		// the lexer is left as it was
+		resumed := NewLexer()
+		assert.Nil(t, resumed.AppendString(`[1`))
+		assert.Equal(t, testCase.expected, resumed.UnmarshalJSON(tampered).Error(), "%s: %v", testCase.field, testCase.value)
+		assert.Equal(t, `[1]`, resumed.CompleteJSON())
+	}
+
+	// the state which would break the next AppendString()
+	assert.NotNil(t, NewLexer().UnmarshalJSON([]byte(`{"version":1,"jsonContent":"[","mirrorTokenStack":[8],"state":1,"streamLength":1,"sourceMap":{"mapped":99}}`)))
+}
@@ -0,0 +1,293 @@
+package streamingjsongo
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// steps of grammar checking in strict mode, the step is what the next byte of JSON stream should be
+const (
+	GRAMMAR_STEP_VALUE                  = iota // beginning of value
+	GRAMMAR_STEP_VALUE_OR_ARRAY_END            // beginning of value or `]`, like `[`
+	GRAMMAR_STEP_KEY                           // beginning of properity key, like `{"a":1,`
+	GRAMMAR_STEP_KEY_OR_OBJECT_END             // beginning of properity key or `}`, like `{`
+	GRAMMAR_STEP_COLON                         // `:` after properity key, like `{"a"`
+	GRAMMAR_STEP_VALUE_END                     // `,` or closer after value in container, like `[1`
+	GRAMMAR_STEP_STRING                        // string content, like `"a`
+	GRAMMAR_STEP_STRING_ESCAPE                 // escaped character, like `"\`
+	GRAMMAR_STEP_STRING_UNICODE_ESCAPE         // hex digit of unicode escape, like `"\u00`
+	GRAMMAR_STEP_LITERAL                       // rest of literal, like `tr`
+	GRAMMAR_STEP_NUMBER_SIGN                   // integer part after negative sign, like `-`
+	GRAMMAR_STEP_NUMBER_ZERO                   // after leading zero, like `-0`
+	GRAMMAR_STEP_NUMBER_INTEGER                // integer part, like `12`
+	GRAMMAR_STEP_NUMBER_DOT                    // first digit of fraction part, like `1.`
+	GRAMMAR_STEP_NUMBER_FRACTION               // fraction part, like `1.2`
+	GRAMMAR_STEP_NUMBER_EXPONENT               // exponent sign or digit, like `1e`
+	GRAMMAR_STEP_NUMBER_EXPONENT_SIGN          // first digit of exponent after sign, like `1e-`
+	GRAMMAR_STEP_NUMBER_EXPONENT_DIGITS        // exponent digits, like `1e2`
+	GRAMMAR_STEP_END                           // only whitespace after root value
+)
+
+// syntax error found in JSON stream in strict mode
+type SyntaxError struct {
+	Offset  int // offset of the offending byte in JSON stream, or length of JSON stream if it ended incomplete
+	Message string
+}
+
+func (syntaxError *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at offset %d: %s", syntaxError.Offset, syntaxError.Message)
+}
+
+// state of grammar checking (RFC 8259) in strict mode
+type grammarChecker struct {
+	enabled    bool
+	err        *SyntaxError // the first syntax error found, the lexer refuses more input after it
+	step       int          // GRAMMAR_STEP_*
+	containers []int        // `{` or `[` of containers on the path of JSON stream cursor
+	inKey      bool         // the string in progress is properity key
+	literal    string       // rest of literal in progress
+	hexDigits  int          // hex digits of unicode escape in progress
+	utf8Bytes  int          // continuation bytes of UTF-8 character in progress
+	utf8Lower  byte         // lowest next continuation byte, it is higher after some first bytes to reject overlong encoding
+	utf8Upper  byte         // highest next continuation byte, it is lower after some first bytes to reject surrogate and beyond U+10FFFF
+}
+
+// new lexer in strict mode, the JSON stream is checked by JSON grammar (RFC 8259) while appending,
+// AppendString() returns *SyntaxError as soon as the offending byte arrived, and Finish() returns it if JSON stream ended incomplete.
+func NewStrictLexer() *Lexer {
+	lexer := NewLexer()
+	lexer.strict.enabled = true
+	return lexer
+}
+
+// end the JSON stream, in strict mode returns *SyntaxError if JSON stream is invalid or incomplete
+func (lexer *Lexer) Finish() error {
+	if !lexer.strict.enabled {
+		return nil
+	}
+	if lexer.strict.err == nil && !lexer.strict.completed() {
+		lexer.strict.err = &SyntaxError{Offset: lexer.streamLength, Message: "unexpected end of JSON stream"}
+	}
+	if lexer.strict.err != nil {
+		return lexer.strict.err
+	}
+	return nil
+}
+
+// check next byte of JSON stream by grammar, the byte is at the offset of stream length
+func (lexer *Lexer) checkGrammar(c byte) error {
+	if message := lexer.strict.scan(c); message != "" {
+		lexer.strict.err = &SyntaxError{Offset: lexer.streamLength, Message: message}
+		return lexer.strict.err
+	}
+	return nil
+}
+
+// check if JSON stream is a complete JSON text, the root number is complete if it ends with digit
+func (checker *grammarChecker) completed() bool {
+	if checker.step == GRAMMAR_STEP_END {
+		return true
+	}
+	return isCompleteNumberStep(checker.step) && len(checker.containers) == 0
+}
+
+// scan next byte of JSON stream, returns message of syntax error or empty string
+func (checker *grammarChecker) scan(c byte) string {
+	switch checker.step {
+	case GRAMMAR_STEP_VALUE, GRAMMAR_STEP_VALUE_OR_ARRAY_END:
+		if isJSONWhitespace(c) {
+			return ""
+		}
+		if c == TOKEN_RIGHT_BRACKET_SYMBOL && checker.step == GRAMMAR_STEP_VALUE_OR_ARRAY_END {
+			return checker.closeContainer(TOKEN_LEFT_BRACKET)
+		}
+		return checker.beginValue(c)
+	case GRAMMAR_STEP_KEY, GRAMMAR_STEP_KEY_OR_OBJECT_END:
+		if isJSONWhitespace(c) {
+			return ""
+		}
+		if c == TOKEN_RIGHT_BRACE_SYMBOL && checker.step == GRAMMAR_STEP_KEY_OR_OBJECT_END {
+			return checker.closeContainer(TOKEN_LEFT_BRACE)
+		}
+		if c != TOKEN_QUOTE_SYMBOL {
+			return fmt.Sprintf("invalid character %q looking for beginning of properity key", []byte{c})
+		}
+		checker.inKey = true
+		checker.step = GRAMMAR_STEP_STRING
+	case GRAMMAR_STEP_COLON:
+		if isJSONWhitespace(c) {
+			return ""
+		}
+		if c != TOKEN_COLON_SYMBOL {
+			return fmt.Sprintf("invalid character %q after properity key", []byte{c})
+		}
+		checker.step = GRAMMAR_STEP_VALUE
+	case GRAMMAR_STEP_VALUE_END:
+		switch {
+		case isJSONWhitespace(c):
+		case c == TOKEN_COMMA_SYMBOL && checker.containers[len(checker.containers)-1] == TOKEN_LEFT_BRACE:
+			checker.step = GRAMMAR_STEP_KEY
+		case c == TOKEN_COMMA_SYMBOL:
+			checker.step = GRAMMAR_STEP_VALUE
+		case c == TOKEN_RIGHT_BRACE_SYMBOL:
+			return checker.closeContainer(TOKEN_LEFT_BRACE)
+		case c == TOKEN_RIGHT_BRACKET_SYMBOL:
+			return checker.closeContainer(TOKEN_LEFT_BRACKET)
+		default:
+			return fmt.Sprintf("invalid character %q after value", []byte{c})
+		}
+	case GRAMMAR_STEP_END:
+		if !isJSONWhitespace(c) {
+			return fmt.Sprintf("invalid character %q after root value", []byte{c})
+		}
+	case GRAMMAR_STEP_STRING:
+		switch {
+		case checker.utf8Bytes > 0 || c >= utf8.RuneSelf:
+			return checker.scanUTF8(c)
+		case c == TOKEN_QUOTE_SYMBOL && checker.inKey:
+			checker.inKey = false
+			checker.step = GRAMMAR_STEP_COLON
+		case c == TOKEN_QUOTE_SYMBOL:
+			checker.endValue()
+		case c == TOKEN_ESCAPE_CHARACTER_SYMBOL:
+			checker.step = GRAMMAR_STEP_STRING_ESCAPE
+		case c < 0x20:
+			return fmt.Sprintf("invalid control character %q in string", []byte{c})
+		}
+	case GRAMMAR_STEP_STRING_ESCAPE:
+		switch {
+		case isShortEscapeCharacter(c):
+			checker.step = GRAMMAR_STEP_STRING
+		case c == TOKEN_ALPHABET_LOWERCASE_U_SYMBOL:
+			checker.hexDigits = 0
+			checker.step = GRAMMAR_STEP_STRING_UNICODE_ESCAPE
+		default:
+			return fmt.Sprintf("invalid character %q in string escape", []byte{c})
+		}
+	case GRAMMAR_STEP_STRING_UNICODE_ESCAPE:
+		if !isHexDigit(c) {
+			return fmt.Sprintf("invalid character %q in unicode escape", []byte{c})
+		}
+		checker.hexDigits++
+		if checker.hexDigits == 4 {
+			checker.step = GRAMMAR_STEP_STRING
+		}
+	case GRAMMAR_STEP_LITERAL:
+		if c != checker.literal[0] {
+			return fmt.Sprintf("invalid character %q in literal", []byte{c})
+		}
+		checker.literal = checker.literal[1:]
+		if len(checker.literal) == 0 {
+			checker.endValue()
+		}
+	case GRAMMAR_STEP_NUMBER_SIGN, GRAMMAR_STEP_NUMBER_ZERO, GRAMMAR_STEP_NUMBER_INTEGER, GRAMMAR_STEP_NUMBER_DOT, GRAMMAR_STEP_NUMBER_FRACTION,
+		GRAMMAR_STEP_NUMBER_EXPONENT, GRAMMAR_STEP_NUMBER_EXPONENT_SIGN, GRAMMAR_STEP_NUMBER_EXPONENT_DIGITS:
+		if step, ok := nextNumberStep(checker.step, c); ok {
+			checker.step = step
+			return ""
+		}
+		if checker.step == GRAMMAR_STEP_NUMBER_ZERO && isDigit(c) {
+			return "invalid digit after leading zero of number"
+		}
+		if isCompleteNumberStep(checker.step) {
+			return checker.endNumber(c)
+		}
+		return invalidNumberMessage(checker.step, c)
+	}
+	return ""
+}
+
+// scan byte of multi-byte UTF-8 character in string (RFC 3629),
+// the overlong encoding, surrogate (like `\xed\xa0\x80`) and code point beyond U+10FFFF are invalid
+func (checker *grammarChecker) scanUTF8(c byte) string {
+	if checker.utf8Bytes > 0 {
+		if c < checker.utf8Lower || c > checker.utf8Upper {
+			return fmt.Sprintf("invalid UTF-8 byte %q in string", []byte{c})
+		}
+		checker.utf8Bytes--
+		checker.utf8Lower, checker.utf8Upper = 0x80, 0xbf
+		return ""
+	}
+	checker.utf8Lower, checker.utf8Upper = 0x80, 0xbf
+	switch {
+	case c >= 0xc2 && c <= 0xdf:
+		checker.utf8Bytes = 1
+	case c == 0xe0:
+		checker.utf8Bytes = 2
+		checker.utf8Lower = 0xa0
+	case c == 0xed:
+		checker.utf8Bytes = 2
+		checker.utf8Upper = 0x9f
+	case c >= 0xe1 && c <= 0xef:
+		checker.utf8Bytes = 2
+	case c == 0xf0:
+		checker.utf8Bytes = 3
+		checker.utf8Lower = 0x90
+	case c == 0xf4:
+		checker.utf8Bytes = 3
+		checker.utf8Upper = 0x8f
+	case c >= 0xf1 && c <= 0xf3:
+		checker.utf8Bytes = 3
+	default:
+		return fmt.Sprintf("invalid UTF-8 byte %q in string", []byte{c})
+	}
+	return ""
+}
+
+// scan first byte of value
+func (checker *grammarChecker) beginValue(c byte) string {
+	switch {
+	case c == TOKEN_LEFT_BRACE_SYMBOL:
+		checker.containers = append(checker.containers, TOKEN_LEFT_BRACE)
+		checker.step = GRAMMAR_STEP_KEY_OR_OBJECT_END
+	case c == TOKEN_LEFT_BRACKET_SYMBOL:
+		checker.containers = append(checker.containers, TOKEN_LEFT_BRACKET)
+		checker.step = GRAMMAR_STEP_VALUE_OR_ARRAY_END
+	case c == TOKEN_QUOTE_SYMBOL:
+		checker.step = GRAMMAR_STEP_STRING
+	case c == TOKEN_NEGATIVE_SYMBOL:
+		checker.step = GRAMMAR_STEP_NUMBER_SIGN
+	case c == TOKEN_NUMBER_0_SYMBOL:
+		checker.step = GRAMMAR_STEP_NUMBER_ZERO
+	case isDigit(c):
+		checker.step = GRAMMAR_STEP_NUMBER_INTEGER
+	case c == TOKEN_ALPHABET_LOWERCASE_T_SYMBOL:
+		checker.literal = "rue"
+		checker.step = GRAMMAR_STEP_LITERAL
+	case c == TOKEN_ALPHABET_LOWERCASE_F_SYMBOL:
+		checker.literal = "alse"
+		checker.step = GRAMMAR_STEP_LITERAL
+	case c == TOKEN_ALPHABET_LOWERCASE_N_SYMBOL:
+		checker.literal = "ull"
+		checker.step = GRAMMAR_STEP_LITERAL
+	default:
+		return fmt.Sprintf("invalid character %q looking for beginning of value", []byte{c})
+	}
+	return ""
+}
+
+// end number by the byte after it, and scan the byte
+func (checker *grammarChecker) endNumber(c byte) string {
+	checker.endValue()
+	return checker.scan(c)
+}
+
+// end value, the next is `,` or closer in container, or only whitespace after root value
+func (checker *grammarChecker) endValue() {
+	if len(checker.containers) == 0 {
+		checker.step = GRAMMAR_STEP_END
+		return
+	}
+	checker.step = GRAMMAR_STEP_VALUE_END
+}
+
+// close the innermost container by closer
+func (checker *grammarChecker) closeContainer(opener int) string {
+	containersLen := len(checker.containers)
+	if checker.containers[containersLen-1] != opener {
+		return fmt.Sprintf("mismatched closer of %s", tokenSymbolMap[checker.containers[containersLen-1]])
+	}
+	checker.containers = checker.containers[:containersLen-1]
+	checker.endValue()
+	return ""
+}
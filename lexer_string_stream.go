@@ -0,0 +1,140 @@
+package streamingjsongo
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// the JSON stream appended so far is consumed, append more JSON segment and read again
+var ErrNeedMoreData = errors.New("need more data in JSON stream")
+
+// reader of decoded (unescaped) content of string value in JSON stream.
+// Read() returns the content arrived so far, ErrNeedMoreData if no new content arrived yet,
+// and io.EOF after the string closed.
+type StringStream struct {
+	lexer   *Lexer
+	pointer string   // JSON pointer (RFC 6901) of the string value
+	tokens  []string // reference tokens of JSON pointer
+	node    *Node    // the string value node, nil if it did not arrive yet
+	decoded int      // length of raw string content decoded
+	pending []byte   // decoded content not read yet
+}
+
+// get reader of decoded content of the string value at JSON pointer (RFC 6901) path, like `/answer` or `/steps/0/title`.
+// the escape sequences split across JSON segments are decoded when they are complete.
+func (lexer *Lexer) StringStream(pointer string) *StringStream {
+	return &StringStream{lexer: lexer, pointer: pointer, tokens: parseJSONPointer(pointer)}
+}
+
+// read decoded content of the string value arrived so far
+func (stream *StringStream) Read(p []byte) (int, error) {
+	if len(stream.pending) == 0 {
+		if err := stream.decode(); err != nil {
+			return 0, err
+		}
+	}
+	if len(stream.pending) == 0 {
+		if stream.node != nil && stream.node.Complete {
+			return 0, io.EOF
+		}
+		return 0, ErrNeedMoreData
+	}
+	n := copy(p, stream.pending)
+	stream.pending = stream.pending[n:]
+	return n, nil
+}
+
+// decode the raw string content arrived since last decoding
+func (stream *StringStream) decode() error {
+	if stream.node == nil {
+		node, err := stream.lexer.lookupNode(stream.pointer, stream.tokens, NODE_KIND_STRING)
+		if node == nil {
+			return err
+		}
+		stream.node = node
+	} else {
+		stream.lexer.updateNodeTree()
+	}
+	raw := stream.node.Raw[1:]
+	if stream.node.Complete {
+		raw = raw[:len(raw)-1]
+	}
+	var consumed int
+	stream.pending, consumed = appendDecodedJSONStringContent(stream.pending[:0], raw[stream.decoded:], stream.node.Complete)
+	stream.decoded += consumed
+	return nil
+}
+
+// decode raw JSON string content (without quotes) and append it to dst, returns length of raw content decoded.
+// the high surrogate escape at the end waits for the low surrogate escape if the string is not complete,
+// the invalid surrogate is decoded as U+FFFD like encoding/json.
+func appendDecodedJSONStringContent(dst []byte, raw string, complete bool) ([]byte, int) {
+	i := 0
+	for i < len(raw) {
+		escapeStart := strings.IndexByte(raw[i:], TOKEN_ESCAPE_CHARACTER_SYMBOL)
+		if escapeStart < 0 {
+			return append(dst, raw[i:]...), len(raw)
+		}
+		dst = append(dst, raw[i:i+escapeStart]...)
+		i += escapeStart
+		if i+1 >= len(raw) {
+			break
+		}
+		if raw[i+1] != TOKEN_ALPHABET_LOWERCASE_U_SYMBOL {
+			dst = append(dst, decodeShortEscapeCharacter(raw[i+1]))
+			i += 2
+			continue
+		}
+		if i+6 > len(raw) {
+			break
+		}
+		r := decodeUnicodeEscapeHex(raw[i+2 : i+6])
+		size := 6
+		if utf16.IsSurrogate(r) {
+			rest := raw[i+6:]
+			if !complete && len(rest) == 0 && r < 0xdc00 {
+				break
+			}
+			r = utf8.RuneError
+			if len(rest) >= 6 && rest[0] == TOKEN_ESCAPE_CHARACTER_SYMBOL && rest[1] == TOKEN_ALPHABET_LOWERCASE_U_SYMBOL {
+				if pair := utf16.DecodeRune(decodeUnicodeEscapeHex(raw[i+2:i+6]), decodeUnicodeEscapeHex(rest[2:6])); pair != utf8.RuneError {
+					r = pair
+					size = 12
+				}
+			}
+		}
+		dst = utf8.AppendRune(dst, r)
+		i += size
+	}
+	return dst, i
+}
+
+// decode escaped character of short escape sequence, like `n` in `\n`
+func decodeShortEscapeCharacter(c byte) byte {
+	switch c {
+	case 'b':
+		return '\b'
+	case 'f':
+		return '\f'
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	}
+	return c
+}
+
+// decode 4 hex digits of unicode escape, U+FFFD if invalid
+func decodeUnicodeEscapeHex(hex string) rune {
+	code, err := strconv.ParseUint(hex, 16, 16)
+	if err != nil {
+		return utf8.RuneError
+	}
+	return rune(code)
+}
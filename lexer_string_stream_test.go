@@ -0,0 +1,92 @@
+package streamingjsongo
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// read all decoded content arrived so far from string stream
+func readStringStream(t *testing.T, stream *StringStream) (string, error) {
+	var content []byte
+	buffer := make([]byte, 3)
+	for {
+		n, err := stream.Read(buffer)
+		content = append(content, buffer[:n]...)
+		if err != nil {
+			return string(content), err
+		}
+		assert.NotEqual(t, 0, n)
+	}
+}
+
+func TestStringStream(t *testing.T) {
+	document := `{"steps": [{"title": "a"}], "answer": "line\n\t\"quoted\" 你好 😀 \ud800x \/\\", "next": 1}`
+	var expected struct {
+		Answer string `json:"answer"`
+	}
+	assert.Nil(t, json.Unmarshal([]byte(document), &expected))
+
+	// append byte by byte, the escape sequences are split across segments
+	lexer := NewLexer()
+	stream := lexer.StringStream("/answer")
+	streamed := ""
+	for i := 0; i < len(document); i++ {
+		lexer.AppendString(document[i : i+1])
+		content, err := readStringStream(t, stream)
+		streamed += content
+		if err == io.EOF {
+			break
+		}
+		assert.Equal(t, ErrNeedMoreData, err)
+	}
+	assert.Equal(t, expected.Answer, streamed)
+	n, err := stream.Read(make([]byte, 8))
+	assert.Equal(t, 0, n)
+	assert.Equal(t, io.EOF, err)
+
+	// append at once
+	lexer = NewLexer()
+	lexer.AppendString(document)
+	content, err := readStringStream(t, lexer.StringStream("/answer"))
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, expected.Answer, content)
+	content, err = readStringStream(t, lexer.StringStream("/steps/0/title"))
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, "a", content)
+}
+
+func TestStringStream_partial(t *testing.T) {
+	lexer := NewLexer()
+	stream := lexer.StringStream("/a~1b")
+	lexer.AppendString(`{"a/b": "hello \u4f`)
+	content, err := readStringStream(t, stream)
+	assert.Equal(t, ErrNeedMoreData, err)
+	assert.Equal(t, "hello ", content)
+
+	// the high surrogate waits for the low surrogate
+	lexer.AppendString(`60 \ud83d`)
+	content, err = readStringStream(t, stream)
+	assert.Equal(t, ErrNeedMoreData, err)
+	assert.Equal(t, "你 ", content)
+	lexer.AppendString(`\ude00"`)
+	content, err = readStringStream(t, stream)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, "😀", content)
+}
+
+func TestStringStream_error(t *testing.T) {
+	lexer := NewLexer()
+	lexer.AppendString(`{"a": 1, "b": [`)
+	_, err := lexer.StringStream("/a").Read(make([]byte, 8))
+	assert.Equal(t, "value at `/a` is not string", err.Error())
+	_, err = lexer.StringStream("/b/x").Read(make([]byte, 8))
+	assert.Equal(t, "no value at `/b/x` in JSON stream", err.Error())
+	_, err = lexer.StringStream("/c").Read(make([]byte, 8))
+	assert.Equal(t, ErrNeedMoreData, err)
+	lexer.AppendString(`]}`)
+	_, err = lexer.StringStream("/c").Read(make([]byte, 8))
+	assert.Equal(t, "no value at `/c` in JSON stream", err.Error())
+}
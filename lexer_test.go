@@ -8,473 +8,493 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestCompleteJSON_base(t *testing.T) {
-	streamingJSONCase := map[string]string{
-		// test case: basic object properity
-		`{`:                                  `{}`,        // mirror stack: [], should remove from stack: [], should push into mirror stack: [`}`]
-		`{}`:                                 `{}`,        // mirror stack: [], should remove from stack: [], should push into mirror stack: []
-		`{"`:                                 `{"":null}`, // mirror stack: [`}`], should remove from stack: [], should push into mirror stack: [`"`, `:`, `n`, `u`, `l`, `l`]
-		`{""`:                                `{"":null}`, // mirror stack: [`"`, `:`, `n`, `u`, `l`, `l`,`}`], should remove from stack: [`"`], should push into mirror stack: []
-		`{"a`:                                `{"a":null}`,
-		`{"a"`:                               `{"a":null}`,
-		`{"a":`:                              `{"a":null}`,
-		`{"a":n`:                             `{"a":null}`,
-		`{"a":nu`:                            `{"a":null}`,
-		`{"a":nul`:                           `{"a":null}`,
-		`{"a":null`:                          `{"a":null}`,
-		`{"a":null , "b`:                     `{"a":null , "b":null}`,
-		`{"a":t`:                             `{"a":true}`,
-		`{"a":tr`:                            `{"a":true}`,
-		`{"a":tru`:                           `{"a":true}`,
-		`{"a":true`:                          `{"a":true}`,
-		`{"a":true,`:                         `{"a":true}`,
-		`{"a":true , "b`:                     `{"a":true , "b":null}`,
-		`{"a":f`:                             `{"a":false}`,
-		`{"a":fa`:                            `{"a":false}`,
-		`{"a":fal`:                           `{"a":false}`,
-		`{"a":fals`:                          `{"a":false}`,
-		`{"a":false`:                         `{"a":false}`,
-		`{"a":false,`:                        `{"a":false}`,
-		`{"a":false , "b`:                    `{"a":false , "b":null}`,
-		`{"a":-`:                             `{"a":0}`,
-		`{"a":12`:                            `{"a":12}`,
-		`{"a":-0`:                            `{"a":-0}`, // @TODO: should be 0, not -0
-		`{"a":-12`:                           `{"a":-12}`,
-		`{"a":12,`:                           `{"a":12}`,
-		`{"a":12.`:                           `{"a":12.0}`,
-		`{"a":12.15`:                         `{"a":12.15}`,
-		`{"a":12.15,`:                        `{"a":12.15}`,
-		`{"a":-12.15,`:                       `{"a":-12.15}`,
-		`{"a":-1.215e,`:                      `{"a":-1.215}`,
-		`{"a":-1.215E,`:                      `{"a":-1.215}`,
-		`{"a":-1.215e1,`:                     `{"a":-1.215e1}`,
-		`{"a":-1.215e-1,`:                    `{"a":-1.215e-1}`,
-		`{"a":-1.215e+1,`:                    `{"a":-1.215e+1}`,
-		`{"a":-1.215E1,`:                     `{"a":-1.215E1}`,
-		`{"a":-1.215E-1,`:                    `{"a":-1.215E-1}`,
-		`{"a":-1.215E+1,`:                    `{"a":-1.215E+1}`,
-		`{"a":-1.215e12`:                     `{"a":-1.215e12}`,
-		`{"a":-1.215E12`:                     `{"a":-1.215E12}`,
-		`{"a":-1.215e12,`:                    `{"a":-1.215e12}`,
-		`{"a":-1.215E12,`:                    `{"a":-1.215E12}`,
-		`{"a":"`:                             `{"a":""}`,
-		`{"a":""`:                            `{"a":""}`,
-		`{"a":"",`:                           `{"a":""}`,
-		`{"a":"string`:                       `{"a":"string"}`,
-		`{"a":"string"`:                      `{"a":"string"}`,
-		`{"a":"string",`:                     `{"a":"string"}`,
-		`{"a":"abcdefghijklmnopqrstuvwxyz",`: `{"a":"abcdefghijklmnopqrstuvwxyz"}`,
-		`{"a":"ABCDEFGHIJKLMNOPQRSTUVWXYZ",`: `{"a":"ABCDEFGHIJKLMNOPQRSTUVWXYZ"}`,
-		`{"a":"0123456789",`:                 `{"a":"0123456789"}`,
-		`{"a":"https://`:                     `{"a":"https://"}`,
-		`{"a":"https://example.com/api/v1`:   `{"a":"https://example.com/api/v1"}`,
-		`{"a":"\u0`:                          `{"a":""}`,
-		`{"a":"\u00`:                         `{"a":""}`,
-		`{"a":"\u004`:                        `{"a":""}`,
-		`{"a":"\u0049`:                       `{"a":"\u0049"}`,
-		`{"a":"\u0049"`:                      `{"a":"\u0049"}`,
-		`{"a":"\u0049",`:                     `{"a":"\u0049"}`,
-		`{"a":"\u0049","b":"`:                `{"a":"\u0049","b":""}`,
-		`{"a":"\u0049","b":"\`:               `{"a":"\u0049","b":""}`,
-		`{"a":"\u0049","b":"\u`:              `{"a":"\u0049","b":""}`,
-		`{"a":"\u0049","b":"\u0`:             `{"a":"\u0049","b":""}`,
-		`{"a":"\u0049","b":"\u00`:            `{"a":"\u0049","b":""}`,
-		`{"a":"\u0049","b":"\u005`:           `{"a":"\u0049","b":""}`,
-		`{"a":"\u0049","b":"\u0050`:          `{"a":"\u0049","b":"\u0050"}`,
-		`{"a":"\u0049","b":"\u0050"`:         `{"a":"\u0049","b":"\u0050"}`,
-		`{"a":"\u0049","b":"\u0050"}`:        `{"a":"\u0049","b":"\u0050"}`,
-		`{"a":"\u0123",`:                     `{"a":"\u0123"}`,
-		`{"a":"\u4567",`:                     `{"a":"\u4567"}`,
-		`{"a":"\u89ab",`:                     `{"a":"\u89ab"}`,
-		`{"a":"\u89AB",`:                     `{"a":"\u89AB"}`,
-		`{"a":"\ucdef",`:                     `{"a":"\ucdef"}`,
-		`{"a":"\ucdee",`:                     `{"a":"\ucdee"}`,
-		`{"a":"\uaaaa",`:                     `{"a":"\uaaaa"}`,
-		`{"a":"\uCDEF",`:                     `{"a":"\uCDEF"}`,
+var completeJSONBaseCases = map[string]string{
+	// test case: basic object properity
+	`{`:                                  `{}`,        // mirror stack: [], should remove from stack: [], should push into mirror stack: [`}`]
+	`{}`:                                 `{}`,        // mirror stack: [], should remove from stack: [], should push into mirror stack: []
+	`{"`:                                 `{"":null}`, // mirror stack: [`}`], should remove from stack: [], should push into mirror stack: [`"`, `:`, `n`, `u`, `l`, `l`]
+	`{""`:                                `{"":null}`, // mirror stack: [`"`, `:`, `n`, `u`, `l`, `l`,`}`], should remove from stack: [`"`], should push into mirror stack: []
+	`{"a`:                                `{"a":null}`,
+	`{"a"`:                               `{"a":null}`,
+	`{"a":`:                              `{"a":null}`,
+	`{"a":n`:                             `{"a":null}`,
+	`{"a":nu`:                            `{"a":null}`,
+	`{"a":nul`:                           `{"a":null}`,
+	`{"a":null`:                          `{"a":null}`,
+	`{"a":null , "b`:                     `{"a":null , "b":null}`,
+	`{"a":t`:                             `{"a":true}`,
+	`{"a":tr`:                            `{"a":true}`,
+	`{"a":tru`:                           `{"a":true}`,
+	`{"a":true`:                          `{"a":true}`,
+	`{"a":true,`:                         `{"a":true}`,
+	`{"a":true , "b`:                     `{"a":true , "b":null}`,
+	`{"a":f`:                             `{"a":false}`,
+	`{"a":fa`:                            `{"a":false}`,
+	`{"a":fal`:                           `{"a":false}`,
+	`{"a":fals`:                          `{"a":false}`,
+	`{"a":false`:                         `{"a":false}`,
+	`{"a":false,`:                        `{"a":false}`,
+	`{"a":false , "b`:                    `{"a":false , "b":null}`,
+	`{"a":-`:                             `{"a":0}`,
+	`{"a":12`:                            `{"a":12}`,
+	`{"a":-0`:                            `{"a":-0}`,
+	`{"a":-12`:                           `{"a":-12}`,
+	`{"a":12,`:                           `{"a":12}`,
+	`{"a":12.`:                           `{"a":12}`,
+	`{"a":12.15`:                         `{"a":12.15}`,
+	`{"a":12.15,`:                        `{"a":12.15}`,
+	`{"a":-12.15,`:                       `{"a":-12.15}`,
+	`{"a":-1.215e,`:                      `{"a":-1.215}`,
+	`{"a":-1.215E,`:                      `{"a":-1.215}`,
+	`{"a":-1.215e1,`:                     `{"a":-1.215e1}`,
+	`{"a":-1.215e-1,`:                    `{"a":-1.215e-1}`,
+	`{"a":-1.215e+1,`:                    `{"a":-1.215e+1}`,
+	`{"a":-1.215E1,`:                     `{"a":-1.215E1}`,
+	`{"a":-1.215E-1,`:                    `{"a":-1.215E-1}`,
+	`{"a":-1.215E+1,`:                    `{"a":-1.215E+1}`,
+	`{"a":-1.215e12`:                     `{"a":-1.215e12}`,
+	`{"a":-1.215E12`:                     `{"a":-1.215E12}`,
+	`{"a":-1.215e12,`:                    `{"a":-1.215e12}`,
+	`{"a":-1.215E12,`:                    `{"a":-1.215E12}`,
+	`{"a":-1.215e+`:                      `{"a":-1.215}`,
+	`{"a":-1.215E-`:                      `{"a":-1.215}`,
+	`{"a":12e`:                           `{"a":12}`,
+	`{"a":12E+`:                          `{"a":12}`,
+	`{"a":-12e-3`:                        `{"a":-12e-3}`,
+	`{"a":"`:                             `{"a":""}`,
+	`{"a":""`:                            `{"a":""}`,
+	`{"a":"",`:                           `{"a":""}`,
+	`{"a":"string`:                       `{"a":"string"}`,
+	`{"a":"string"`:                      `{"a":"string"}`,
+	`{"a":"string",`:                     `{"a":"string"}`,
+	`{"a":"abcdefghijklmnopqrstuvwxyz",`: `{"a":"abcdefghijklmnopqrstuvwxyz"}`,
+	`{"a":"ABCDEFGHIJKLMNOPQRSTUVWXYZ",`: `{"a":"ABCDEFGHIJKLMNOPQRSTUVWXYZ"}`,
+	`{"a":"0123456789",`:                 `{"a":"0123456789"}`,
+	`{"a":"https://`:                     `{"a":"https://"}`,
+	`{"a":"https://example.com/api/v1`:   `{"a":"https://example.com/api/v1"}`,
+	`{"a":"\u0`:                          `{"a":""}`,
+	`{"a":"\u00`:                         `{"a":""}`,
+	`{"a":"\u004`:                        `{"a":""}`,
+	`{"a":"\u0049`:                       `{"a":"\u0049"}`,
+	`{"a":"\u0049"`:                      `{"a":"\u0049"}`,
+	`{"a":"\u0049",`:                     `{"a":"\u0049"}`,
+	`{"a":"\u0049","b":"`:                `{"a":"\u0049","b":""}`,
+	`{"a":"\u0049","b":"\`:               `{"a":"\u0049","b":""}`,
+	`{"a":"\u0049","b":"\u`:              `{"a":"\u0049","b":""}`,
+	`{"a":"\u0049","b":"\u0`:             `{"a":"\u0049","b":""}`,
+	`{"a":"\u0049","b":"\u00`:            `{"a":"\u0049","b":""}`,
+	`{"a":"\u0049","b":"\u005`:           `{"a":"\u0049","b":""}`,
+	`{"a":"\u0049","b":"\u0050`:          `{"a":"\u0049","b":"\u0050"}`,
+	`{"a":"\u0049","b":"\u0050"`:         `{"a":"\u0049","b":"\u0050"}`,
+	`{"a":"\u0049","b":"\u0050"}`:        `{"a":"\u0049","b":"\u0050"}`,
+	`{"a":"\u0123",`:                     `{"a":"\u0123"}`,
+	`{"a":"\u4567",`:                     `{"a":"\u4567"}`,
+	`{"a":"\u89ab",`:                     `{"a":"\u89ab"}`,
+	`{"a":"\u89AB",`:                     `{"a":"\u89AB"}`,
+	`{"a":"\ucdef",`:                     `{"a":"\ucdef"}`,
+	`{"a":"\ucdee",`:                     `{"a":"\ucdee"}`,
+	`{"a":"\uaaaa",`:                     `{"a":"\uaaaa"}`,
+	`{"a":"\uCDEF",`:                     `{"a":"\uCDEF"}`,
 
-		// test case: escape character
-		`{"\`:          `{"":null}`,
-		`{"\"`:         `{"\"":null}`,
-		`{"\""`:        `{"\"":null}`,
-		`{"\"\`:        `{"\"":null}`,
-		`{"\"\""`:      `{"\"\"":null}`,
-		`{"\"":`:       `{"\"":null}`,
-		`{"a":"\"`:     `{"a":"\""}`,
-		`{"a":"\""`:    `{"a":"\""}`,
-		`{"a":"\"\"`:   `{"a":"\"\""}`,
-		`{"a":"\"\""`:  `{"a":"\"\""}`,
-		`{"a":"\"\"",`: `{"a":"\"\""}`,
-		`{"a":"\"\""}`: `{"a":"\"\""}`,
-		`{"\\`:         `{"\\":null}`,
-		`{"\/`:         `{"\/":null}`,
-		`{"/`:          `{"/":null}`,
-		`{"\b`:         `{"\b":null}`,
-		`{"\f`:         `{"\f":null}`,
-		`{"\n`:         `{"\n":null}`,
-		`{"\r`:         `{"\r":null}`,
-		`{"\t`:         `{"\t":null}`,
-		`{"\u0111`:     `{"\u0111":null}`,
+	// test case: escape character
+	`{"\`:          `{"":null}`,
+	`{"\"`:         `{"\"":null}`,
+	`{"\""`:        `{"\"":null}`,
+	`{"\"\`:        `{"\"":null}`,
+	`{"\"\""`:      `{"\"\"":null}`,
+	`{"\"":`:       `{"\"":null}`,
+	`{"a":"\"`:     `{"a":"\""}`,
+	`{"a":"\""`:    `{"a":"\""}`,
+	`{"a":"\"\"`:   `{"a":"\"\""}`,
+	`{"a":"\"\""`:  `{"a":"\"\""}`,
+	`{"a":"\"\"",`: `{"a":"\"\""}`,
+	`{"a":"\"\""}`: `{"a":"\"\""}`,
+	`{"\\`:         `{"\\":null}`,
+	`{"\/`:         `{"\/":null}`,
+	`{"/`:          `{"/":null}`,
+	`{"\b`:         `{"\b":null}`,
+	`{"\f`:         `{"\f":null}`,
+	`{"\n`:         `{"\n":null}`,
+	`{"\r`:         `{"\r":null}`,
+	`{"\t`:         `{"\t":null}`,
+	`{"\u0111`:     `{"\u0111":null}`,
 
-		// test case: token in string
-		`{"a":"["`:          `{"a":"["}`,
-		`{"a":"[]"`:         `{"a":"[]"}`,
-		`{"a":"]"`:          `{"a":"]"}`,
-		`{"a":"{"`:          `{"a":"{"}`,
-		`{"a":"{}"`:         `{"a":"{}"}`,
-		`{"a":"}"`:          `{"a":"}"}`,
-		`{"a":","`:          `{"a":","}`,
-		`{"a":"."`:          `{"a":"."}`,
-		`{"a":"","`:         `{"a":"","":null}`,
-		`{"a":"","b`:        `{"a":"","b":null}`,
-		`{"a":"","b"`:       `{"a":"","b":null}`,
-		`{"a":"","b":`:      `{"a":"","b":null}`,
-		`{"a":"","b":"`:     `{"a":"","b":""}`,
-		`{"a":"","b":""`:    `{"a":"","b":""}`,
-		`{"a":"","b":""}`:   `{"a":"","b":""}`,
-		`{"1`:               `{"1":null}`,
-		`{"1.`:              `{"1.":null}`,
-		`{"1.1`:             `{"1.1":null}`,
-		`{"1.10`:            `{"1.10":null}`,
-		`{"1"`:              `{"1":null}`,
-		`{"1":`:             `{"1":null}`,
-		`{"1":"`:            `{"1":""}`,
-		`{"1":"1`:           `{"1":"1"}`,
-		`{"1":"1.`:          `{"1":"1."}`,
-		`{"1":"1.1`:         `{"1":"1.1"}`,
-		`{"1":"1.10`:        `{"1":"1.10"}`,
-		`{"1":"1"`:          `{"1":"1"}`,
-		`{"1":"1"}`:         `{"1":"1"}`,
-		`{"-1":"-1"}`:       `{"-1":"-1"}`,
-		`{"t`:               `{"t":null}`,
-		`{"tr`:              `{"tr":null}`,
-		`{"tru`:             `{"tru":null}`,
-		`{"true`:            `{"true":null}`,
-		`{"true"`:           `{"true":null}`,
-		`{"true":`:          `{"true":null}`,
-		`{"true":"t`:        `{"true":"t"}`,
-		`{"true":"tr`:       `{"true":"tr"}`,
-		`{"true":"tru`:      `{"true":"tru"}`,
-		`{"true":"true`:     `{"true":"true"}`,
-		`{"true":"true"`:    `{"true":"true"}`,
-		`{"true":"true"}`:   `{"true":"true"}`,
-		`{"f`:               `{"f":null}`,
-		`{"fa`:              `{"fa":null}`,
-		`{"fal`:             `{"fal":null}`,
-		`{"fals`:            `{"fals":null}`,
-		`{"false`:           `{"false":null}`,
-		`{"false"`:          `{"false":null}`,
-		`{"false":`:         `{"false":null}`,
-		`{"false":"f`:       `{"false":"f"}`,
-		`{"false":"fa`:      `{"false":"fa"}`,
-		`{"false":"fal`:     `{"false":"fal"}`,
-		`{"false":"fals`:    `{"false":"fals"}`,
-		`{"false":"false`:   `{"false":"false"}`,
-		`{"false":"false"`:  `{"false":"false"}`,
-		`{"false":"false"}`: `{"false":"false"}`,
-		`{"n`:               `{"n":null}`,
-		`{"nu`:              `{"nu":null}`,
-		`{"nul`:             `{"nul":null}`,
-		`{"null`:            `{"null":null}`,
-		`{"null"`:           `{"null":null}`,
-		`{"null":`:          `{"null":null}`,
-		`{"null":"n`:        `{"null":"n"}`,
-		`{"null":"nu`:       `{"null":"nu"}`,
-		`{"null":"nul`:      `{"null":"nul"}`,
-		`{"null":"null`:     `{"null":"null"}`,
-		`{"null":"null"`:    `{"null":"null"}`,
-		`{"null":"null"}`:   `{"null":"null"}`,
+	// test case: token in string
+	`{"a":"["`:          `{"a":"["}`,
+	`{"a":"[]"`:         `{"a":"[]"}`,
+	`{"a":"]"`:          `{"a":"]"}`,
+	`{"a":"{"`:          `{"a":"{"}`,
+	`{"a":"{}"`:         `{"a":"{}"}`,
+	`{"a":"}"`:          `{"a":"}"}`,
+	`{"a":","`:          `{"a":","}`,
+	`{"a":"."`:          `{"a":"."}`,
+	`{"a":"","`:         `{"a":"","":null}`,
+	`{"a":"","b`:        `{"a":"","b":null}`,
+	`{"a":"","b"`:       `{"a":"","b":null}`,
+	`{"a":"","b":`:      `{"a":"","b":null}`,
+	`{"a":"","b":"`:     `{"a":"","b":""}`,
+	`{"a":"","b":""`:    `{"a":"","b":""}`,
+	`{"a":"","b":""}`:   `{"a":"","b":""}`,
+	`{"1`:               `{"1":null}`,
+	`{"1.`:              `{"1.":null}`,
+	`{"1.1`:             `{"1.1":null}`,
+	`{"1.10`:            `{"1.10":null}`,
+	`{"1"`:              `{"1":null}`,
+	`{"1":`:             `{"1":null}`,
+	`{"1":"`:            `{"1":""}`,
+	`{"1":"1`:           `{"1":"1"}`,
+	`{"1":"1.`:          `{"1":"1."}`,
+	`{"1":"1.1`:         `{"1":"1.1"}`,
+	`{"1":"1.10`:        `{"1":"1.10"}`,
+	`{"1":"1"`:          `{"1":"1"}`,
+	`{"1":"1"}`:         `{"1":"1"}`,
+	`{"-1":"-1"}`:       `{"-1":"-1"}`,
+	`{"t`:               `{"t":null}`,
+	`{"tr`:              `{"tr":null}`,
+	`{"tru`:             `{"tru":null}`,
+	`{"true`:            `{"true":null}`,
+	`{"true"`:           `{"true":null}`,
+	`{"true":`:          `{"true":null}`,
+	`{"true":"t`:        `{"true":"t"}`,
+	`{"true":"tr`:       `{"true":"tr"}`,
+	`{"true":"tru`:      `{"true":"tru"}`,
+	`{"true":"true`:     `{"true":"true"}`,
+	`{"true":"true"`:    `{"true":"true"}`,
+	`{"true":"true"}`:   `{"true":"true"}`,
+	`{"f`:               `{"f":null}`,
+	`{"fa`:              `{"fa":null}`,
+	`{"fal`:             `{"fal":null}`,
+	`{"fals`:            `{"fals":null}`,
+	`{"false`:           `{"false":null}`,
+	`{"false"`:          `{"false":null}`,
+	`{"false":`:         `{"false":null}`,
+	`{"false":"f`:       `{"false":"f"}`,
+	`{"false":"fa`:      `{"false":"fa"}`,
+	`{"false":"fal`:     `{"false":"fal"}`,
+	`{"false":"fals`:    `{"false":"fals"}`,
+	`{"false":"false`:   `{"false":"false"}`,
+	`{"false":"false"`:  `{"false":"false"}`,
+	`{"false":"false"}`: `{"false":"false"}`,
+	`{"n`:               `{"n":null}`,
+	`{"nu`:              `{"nu":null}`,
+	`{"nul`:             `{"nul":null}`,
+	`{"null`:            `{"null":null}`,
+	`{"null"`:           `{"null":null}`,
+	`{"null":`:          `{"null":null}`,
+	`{"null":"n`:        `{"null":"n"}`,
+	`{"null":"nu`:       `{"null":"nu"}`,
+	`{"null":"nul`:      `{"null":"nul"}`,
+	`{"null":"null`:     `{"null":"null"}`,
+	`{"null":"null"`:    `{"null":"null"}`,
+	`{"null":"null"}`:   `{"null":"null"}`,
 
-		// test case: array as object value
-		`{"a":[`:            `{"a":[]}`,
-		`{"a":[]`:           `{"a":[]}`,
-		`{"a":[1`:           `{"a":[1]}`,
-		`{"a":[1,`:          `{"a":[1]}`,
-		`{"a":[-0,`:         `{"a":[-0]}`, // @TODO: should be 0, not -0
-		`{"a":[-1,`:         `{"a":[-1]}`,
-		`{"a":[1,0`:         `{"a":[1,0]}`,
-		`{"a":[1,0.0`:       `{"a":[1,0.0]}`,
-		`{"a":[1,0.01`:      `{"a":[1,0.01]}`,
-		`{"a":[1,0.01]`:     `{"a":[1,0.01]}`,
-		`{"a":[1,0.01]}`:    `{"a":[1,0.01]}`,
-		`{"a":[-1,0.01]}`:   `{"a":[-1,0.01]}`,
-		`{"a":[-1,-`:        `{"a":[-1,0]}`,
-		`{"a":[-1,-0`:       `{"a":[-1,-0]}`, // @TODO: should be 0, not -0
-		`{"a":[1,-0.01]}`:   `{"a":[1,-0.01]}`,
-		`{"a":[-1,-0.01]}`:  `{"a":[-1,-0.01]}`,
-		`{"a":[n`:           `{"a":[null]}`,
-		`{"a":[nu`:          `{"a":[null]}`,
-		`{"a":[nul`:         `{"a":[null]}`,
-		`{"a":[null`:        `{"a":[null]}`,
-		`{"a":[null,`:       `{"a":[null]}`,
-		`{"a":[null]`:       `{"a":[null]}`,
-		`{"a":[null]}`:      `{"a":[null]}`,
-		`{"a":[t`:           `{"a":[true]}`,
-		`{"a":[tr`:          `{"a":[true]}`,
-		`{"a":[tru`:         `{"a":[true]}`,
-		`{"a":[true`:        `{"a":[true]}`,
-		`{"a":[true,`:       `{"a":[true]}`,
-		`{"a":[true]`:       `{"a":[true]}`,
-		`{"a":[true]}`:      `{"a":[true]}`,
-		`{"a":[f`:           `{"a":[false]}`,
-		`{"a":[fa`:          `{"a":[false]}`,
-		`{"a":[fal`:         `{"a":[false]}`,
-		`{"a":[fals`:        `{"a":[false]}`,
-		`{"a":[false`:       `{"a":[false]}`,
-		`{"a":[false,`:      `{"a":[false]}`,
-		`{"a":[false]`:      `{"a":[false]}`,
-		`{"a":[false]}`:     `{"a":[false]}`,
-		`{"a":["`:           `{"a":[""]}`,
-		`{"a":["b`:          `{"a":["b"]}`,
-		`{"a":["b"`:         `{"a":["b"]}`,
-		`{"a":["b",`:        `{"a":["b"]}`,
-		`{"a":["b"]`:        `{"a":["b"]}`,
-		`{"a":["b"]}`:       `{"a":["b"]}`,
-		`{"a":[{`:           `{"a":[{}]}`,
-		`{"a":[{"`:          `{"a":[{"":null}]}`,
-		`{"a":[{"b`:         `{"a":[{"b":null}]}`,
-		`{"a":[{"b"`:        `{"a":[{"b":null}]}`,
-		`{"a":[{"b":`:       `{"a":[{"b":null}]}`,
-		`{"a":[{"b":"`:      `{"a":[{"b":""}]}`,
-		`{"a":[{"b":"c`:     `{"a":[{"b":"c"}]}`,
-		`{"a":[{"b":"c"`:    `{"a":[{"b":"c"}]}`,
-		`{"a":[{"b":"c",`:   `{"a":[{"b":"c"}]}`,
-		`{"a":[{"b":"c"}`:   `{"a":[{"b":"c"}]}`,
-		`{"a":[{"b":"c"}]`:  `{"a":[{"b":"c"}]}`,
-		`{"a":[{"b":"c"}]}`: `{"a":[{"b":"c"}]}`,
+	// test case: array as object value
+	`{"a":[`:            `{"a":[]}`,
+	`{"a":[]`:           `{"a":[]}`,
+	`{"a":[1`:           `{"a":[1]}`,
+	`{"a":[1,`:          `{"a":[1]}`,
+	`{"a":[-0,`:         `{"a":[-0]}`,
+	`{"a":[-1,`:         `{"a":[-1]}`,
+	`{"a":[1,0`:         `{"a":[1,0]}`,
+	`{"a":[1,0.0`:       `{"a":[1,0.0]}`,
+	`{"a":[1,0.01`:      `{"a":[1,0.01]}`,
+	`{"a":[1,0.01]`:     `{"a":[1,0.01]}`,
+	`{"a":[1,0.01]}`:    `{"a":[1,0.01]}`,
+	`{"a":[-1,0.01]}`:   `{"a":[-1,0.01]}`,
+	`{"a":[-1,-`:        `{"a":[-1,0]}`,
+	`{"a":[-1,-0`:       `{"a":[-1,-0]}`,
+	`{"a":[1,-0.01]}`:   `{"a":[1,-0.01]}`,
+	`{"a":[-1,-0.01]}`:  `{"a":[-1,-0.01]}`,
+	`{"a":[n`:           `{"a":[null]}`,
+	`{"a":[nu`:          `{"a":[null]}`,
+	`{"a":[nul`:         `{"a":[null]}`,
+	`{"a":[null`:        `{"a":[null]}`,
+	`{"a":[null,`:       `{"a":[null]}`,
+	`{"a":[null]`:       `{"a":[null]}`,
+	`{"a":[null]}`:      `{"a":[null]}`,
+	`{"a":[t`:           `{"a":[true]}`,
+	`{"a":[tr`:          `{"a":[true]}`,
+	`{"a":[tru`:         `{"a":[true]}`,
+	`{"a":[true`:        `{"a":[true]}`,
+	`{"a":[true,`:       `{"a":[true]}`,
+	`{"a":[true]`:       `{"a":[true]}`,
+	`{"a":[true]}`:      `{"a":[true]}`,
+	`{"a":[f`:           `{"a":[false]}`,
+	`{"a":[fa`:          `{"a":[false]}`,
+	`{"a":[fal`:         `{"a":[false]}`,
+	`{"a":[fals`:        `{"a":[false]}`,
+	`{"a":[false`:       `{"a":[false]}`,
+	`{"a":[false,`:      `{"a":[false]}`,
+	`{"a":[false]`:      `{"a":[false]}`,
+	`{"a":[false]}`:     `{"a":[false]}`,
+	`{"a":["`:           `{"a":[""]}`,
+	`{"a":["b`:          `{"a":["b"]}`,
+	`{"a":["b"`:         `{"a":["b"]}`,
+	`{"a":["b",`:        `{"a":["b"]}`,
+	`{"a":["b"]`:        `{"a":["b"]}`,
+	`{"a":["b"]}`:       `{"a":["b"]}`,
+	`{"a":[{`:           `{"a":[{}]}`,
+	`{"a":[{"`:          `{"a":[{"":null}]}`,
+	`{"a":[{"b`:         `{"a":[{"b":null}]}`,
+	`{"a":[{"b"`:        `{"a":[{"b":null}]}`,
+	`{"a":[{"b":`:       `{"a":[{"b":null}]}`,
+	`{"a":[{"b":"`:      `{"a":[{"b":""}]}`,
+	`{"a":[{"b":"c`:     `{"a":[{"b":"c"}]}`,
+	`{"a":[{"b":"c"`:    `{"a":[{"b":"c"}]}`,
+	`{"a":[{"b":"c",`:   `{"a":[{"b":"c"}]}`,
+	`{"a":[{"b":"c"}`:   `{"a":[{"b":"c"}]}`,
+	`{"a":[{"b":"c"}]`:  `{"a":[{"b":"c"}]}`,
+	`{"a":[{"b":"c"}]}`: `{"a":[{"b":"c"}]}`,
 
-		// test case: object as object value
-		`{"a":{`:          `{"a":{}}`,
-		`{"a":{"`:         `{"a":{"":null}}`,
-		`{"a":{"b`:        `{"a":{"b":null}}`,
-		`{"a":{"b"`:       `{"a":{"b":null}}`,
-		`{"a":{"b":`:      `{"a":{"b":null}}`,
-		`{"a":{"b":"`:     `{"a":{"b":""}}`,
-		`{"a":{"b":"c`:    `{"a":{"b":"c"}}`,
-		`{"a":{"b":"c"`:   `{"a":{"b":"c"}}`,
-		`{"a":{"b":"c",`:  `{"a":{"b":"c"}}`,
-		`{"a":{"b":"c"}`:  `{"a":{"b":"c"}}`,
-		`{"a":{"b":"c"}}`: `{"a":{"b":"c"}}`,
+	// test case: object as object value
+	`{"a":{`:          `{"a":{}}`,
+	`{"a":{"`:         `{"a":{"":null}}`,
+	`{"a":{"b`:        `{"a":{"b":null}}`,
+	`{"a":{"b"`:       `{"a":{"b":null}}`,
+	`{"a":{"b":`:      `{"a":{"b":null}}`,
+	`{"a":{"b":"`:     `{"a":{"b":""}}`,
+	`{"a":{"b":"c`:    `{"a":{"b":"c"}}`,
+	`{"a":{"b":"c"`:   `{"a":{"b":"c"}}`,
+	`{"a":{"b":"c",`:  `{"a":{"b":"c"}}`,
+	`{"a":{"b":"c"}`:  `{"a":{"b":"c"}}`,
+	`{"a":{"b":"c"}}`: `{"a":{"b":"c"}}`,
 
-		// test case: multiple object properity
-		`{"a":1,"b":1.20,"c":0.03,"d":-1,"e":-1.20,"f":-0.03,"g":1.997e3,"h":-1.338e19,"i":"a","j":null,"k":true,"l":false,"m":{},"n":[]]}`: `{"a":1,"b":1.20,"c":0.03,"d":-1,"e":-1.20,"f":-0.03,"g":1.997e3,"h":-1.338e19,"i":"a","j":null,"k":true,"l":false,"m":{},"n":[]]}`,
+	// test case: multiple object properity
+	`{"a":1,"b":1.20,"c":0.03,"d":-1,"e":-1.20,"f":-0.03,"g":1.997e3,"h":-1.338e19,"i":"a","j":null,"k":true,"l":false,"m":{},"n":[]]}`: `{"a":1,"b":1.20,"c":0.03,"d":-1,"e":-1.20,"f":-0.03,"g":1.997e3,"h":-1.338e19,"i":"a","j":null,"k":true,"l":false,"m":{},"n":[]]}`,
 
-		// test case: basic array element
-		`[`:                            `[]`,
-		`[]`:                           `[]`,
-		`[n`:                           `[null]`,
-		`[nu`:                          `[null]`,
-		`[nul`:                         `[null]`,
-		`[null`:                        `[null]`,
-		`[null,`:                       `[null]`,
-		`[null,null`:                   `[null,null]`,
-		`[t`:                           `[true]`,
-		`[tr`:                          `[true]`,
-		`[tru`:                         `[true]`,
-		`[true`:                        `[true]`,
-		`[true,`:                       `[true]`,
-		`[true,true`:                   `[true,true]`,
-		`[f`:                           `[false]`,
-		`[fa`:                          `[false]`,
-		`[fal`:                         `[false]`,
-		`[fals`:                        `[false]`,
-		`[false`:                       `[false]`,
-		`[false,`:                      `[false]`,
-		`[false,false`:                 `[false,false]`,
-		`[0`:                           `[0]`,
-		`[-`:                           `[0]`,
-		`[-1`:                          `[-1]`,
-		`[0,`:                          `[0]`,
-		`[-1,`:                         `[-1]`,
-		`[-1,-`:                        `[-1,0]`,
-		`[0.`:                          `[0.0]`,
-		`[-0.`:                         `[-0.0]`,
-		`[0.1`:                         `[0.1]`,
-		`[0.12,`:                       `[0.12]`,
-		`[-0.12,`:                      `[-0.12]`,
-		`[1,2,`:                        `[1,2]`,
-		`[1,2,0`:                       `[1,2,0]`,
-		`[1,2,0.`:                      `[1,2,0.0]`,
-		`[1,2,0.1`:                     `[1,2,0.1]`,
-		`[1,2,0.10`:                    `[1,2,0.10]`,
-		`[-1,2,0.10`:                   `[-1,2,0.10]`,
-		`[-1,-2,0.10`:                  `[-1,-2,0.10]`,
-		`[-1,-2,-0.10`:                 `[-1,-2,-0.10]`,
-		`[1,-2,-0.10`:                  `[1,-2,-0.10]`,
-		`[1,2,-0.10`:                   `[1,2,-0.10]`,
-		`[1,-2,0.10`:                   `[1,-2,0.10]`,
-		`[2.998e`:                      `[2.998]`,
-		`[2.998E`:                      `[2.998]`,
-		`[2.998e1`:                     `[2.998e1]`,
-		`[2.998e-1`:                    `[2.998e-1]`,
-		`[2.998e+1`:                    `[2.998e+1]`,
-		`[2.998E1`:                     `[2.998E1]`,
-		`[2.998E-1`:                    `[2.998E-1]`,
-		`[2.998E+1`:                    `[2.998E+1]`,
-		`[2.998e10`:                    `[2.998e10]`,
-		`[2.998E10`:                    `[2.998E10]`,
-		`[2.998e10,`:                   `[2.998e10]`,
-		`[2.998E10,`:                   `[2.998E10]`,
-		`[-2.998e`:                     `[-2.998]`,
-		`[-2.998E`:                     `[-2.998]`,
-		`[-2.998e1`:                    `[-2.998e1]`,
-		`[-2.998e-1`:                   `[-2.998e-1]`,
-		`[-2.998e+1`:                   `[-2.998e+1]`,
-		`[-2.998E1`:                    `[-2.998E1]`,
-		`[-2.998E-1`:                   `[-2.998E-1]`,
-		`[-2.998E+1`:                   `[-2.998E+1]`,
-		`[-2.998e10`:                   `[-2.998e10]`,
-		`[-2.998E10`:                   `[-2.998E10]`,
-		`[2.998e10,1`:                  `[2.998e10,1]`,
-		`[2.998e10,1.0`:                `[2.998e10,1.0]`,
-		`[2.998e10,1.02`:               `[2.998e10,1.02]`,
-		`[2.998e10,1.02e`:              `[2.998e10,1.02]`,
-		`[2.998e10,1.02e8`:             `[2.998e10,1.02e8]`,
-		`[2.998E10,1.02E8`:             `[2.998E10,1.02E8]`,
-		`[2.998e10,1.02e8,`:            `[2.998e10,1.02e8]`,
-		`[2.998E10,1.02E8,`:            `[2.998E10,1.02E8]`,
-		`["`:                           `[""]`,
-		`[""`:                          `[""]`,
-		`["",`:                         `[""]`,
-		`["a`:                          `["a"]`,
-		`["a"`:                         `["a"]`,
-		`["a",`:                        `["a"]`,
-		`["a","`:                       `["a",""]`,
-		`["a","b`:                      `["a","b"]`,
-		`["a","b"`:                     `["a","b"]`,
-		`["a","b",`:                    `["a","b"]`,
-		`["a","b"]`:                    `["a","b"]`,
-		`["https://example.com/api/v1`: `["https://example.com/api/v1"]`,
-		`["\u0`:                        `[""]`,
-		`["\u00`:                       `[""]`,
-		`["\u004`:                      `[""]`,
-		`["\u0049`:                     `["\u0049"]`,
-		`["\u0049"`:                    `["\u0049"]`,
-		`["\u0049",`:                   `["\u0049"]`,
-		`["\u0049","`:                  `["\u0049",""]`,
-		`["\u0049","\`:                 `["\u0049",""]`,
-		`["\u0049","\u`:                `["\u0049",""]`,
-		`["\u0049","\u0`:               `["\u0049",""]`,
-		`["\u0049","\u00`:              `["\u0049",""]`,
-		`["\u0049","\u005`:             `["\u0049",""]`,
-		`["https://example.com/api/v1", "http://example.com:8080/user/sample_user/index.html`: `["https://example.com/api/v1", "http://example.com:8080/user/sample_user/index.html"]`,
-		`["\u0049","\u0050`:   `["\u0049","\u0050"]`,
-		`["\u0049","\u0050"`:  `["\u0049","\u0050"]`,
-		`["\u0049","\u0050"]`: `["\u0049","\u0050"]`,
-		`["\u0123`:            `["\u0123"]`,
-		`["\u4567`:            `["\u4567"]`,
-		`["\u89ab`:            `["\u89ab"]`,
-		`["\u89AB`:            `["\u89AB"]`,
-		`["\ucdef`:            `["\ucdef"]`,
-		`["\uCDEF`:            `["\uCDEF"]`,
+	// test case: basic array element
+	`[`:                            `[]`,
+	`[]`:                           `[]`,
+	`[n`:                           `[null]`,
+	`[nu`:                          `[null]`,
+	`[nul`:                         `[null]`,
+	`[null`:                        `[null]`,
+	`[null,`:                       `[null]`,
+	`[null,null`:                   `[null,null]`,
+	`[t`:                           `[true]`,
+	`[tr`:                          `[true]`,
+	`[tru`:                         `[true]`,
+	`[true`:                        `[true]`,
+	`[true,`:                       `[true]`,
+	`[true,true`:                   `[true,true]`,
+	`[f`:                           `[false]`,
+	`[fa`:                          `[false]`,
+	`[fal`:                         `[false]`,
+	`[fals`:                        `[false]`,
+	`[false`:                       `[false]`,
+	`[false,`:                      `[false]`,
+	`[false,false`:                 `[false,false]`,
+	`[0`:                           `[0]`,
+	`[-`:                           `[0]`,
+	`[-1`:                          `[-1]`,
+	`[0,`:                          `[0]`,
+	`[-1,`:                         `[-1]`,
+	`[-1,-`:                        `[-1,0]`,
+	`[0.`:                          `[0]`,
+	`[-0.`:                         `[-0]`,
+	`[-0`:                          `[-0]`,
+	`[-0,`:                         `[-0]`,
+	`[1.`:                          `[1]`,
+	`[1.]`:                         `[1]`,
+	`[1 2`:                         `[1]`,
+	`[0.1`:                         `[0.1]`,
+	`[0.12,`:                       `[0.12]`,
+	`[-0.12,`:                      `[-0.12]`,
+	`[1,2,`:                        `[1,2]`,
+	`[1,2,0`:                       `[1,2,0]`,
+	`[1,2,0.`:                      `[1,2,0]`,
+	`[1,2,0.1`:                     `[1,2,0.1]`,
+	`[1,2,0.10`:                    `[1,2,0.10]`,
+	`[-1,2,0.10`:                   `[-1,2,0.10]`,
+	`[-1,-2,0.10`:                  `[-1,-2,0.10]`,
+	`[-1,-2,-0.10`:                 `[-1,-2,-0.10]`,
+	`[1,-2,-0.10`:                  `[1,-2,-0.10]`,
+	`[1,2,-0.10`:                   `[1,2,-0.10]`,
+	`[1,-2,0.10`:                   `[1,-2,0.10]`,
+	`[2.998e`:                      `[2.998]`,
+	`[2.998E`:                      `[2.998]`,
+	`[2.998e1`:                     `[2.998e1]`,
+	`[2.998e-1`:                    `[2.998e-1]`,
+	`[2.998e+1`:                    `[2.998e+1]`,
+	`[2.998E1`:                     `[2.998E1]`,
+	`[2.998E-1`:                    `[2.998E-1]`,
+	`[2.998E+1`:                    `[2.998E+1]`,
+	`[2.998e10`:                    `[2.998e10]`,
+	`[2.998E10`:                    `[2.998E10]`,
+	`[2.998e10,`:                   `[2.998e10]`,
+	`[2.998E10,`:                   `[2.998E10]`,
+	`[-2.998e`:                     `[-2.998]`,
+	`[-2.998E`:                     `[-2.998]`,
+	`[-2.998e1`:                    `[-2.998e1]`,
+	`[-2.998e-1`:                   `[-2.998e-1]`,
+	`[-2.998e+1`:                   `[-2.998e+1]`,
+	`[-2.998E1`:                    `[-2.998E1]`,
+	`[-2.998E-1`:                   `[-2.998E-1]`,
+	`[-2.998E+1`:                   `[-2.998E+1]`,
+	`[-2.998e10`:                   `[-2.998e10]`,
+	`[-2.998E10`:                   `[-2.998E10]`,
+	`[2.998e10,1`:                  `[2.998e10,1]`,
+	`[2.998e10,1.0`:                `[2.998e10,1.0]`,
+	`[2.998e10,1.02`:               `[2.998e10,1.02]`,
+	`[2.998e10,1.02e`:              `[2.998e10,1.02]`,
+	`[2.998e10,1.02e8`:             `[2.998e10,1.02e8]`,
+	`[2.998E10,1.02E8`:             `[2.998E10,1.02E8]`,
+	`[2.998e10,1.02e8,`:            `[2.998e10,1.02e8]`,
+	`[2.998E10,1.02E8,`:            `[2.998E10,1.02E8]`,
+	`["`:                           `[""]`,
+	`[""`:                          `[""]`,
+	`["",`:                         `[""]`,
+	`["a`:                          `["a"]`,
+	`["a"`:                         `["a"]`,
+	`["a",`:                        `["a"]`,
+	`["a","`:                       `["a",""]`,
+	`["a","b`:                      `["a","b"]`,
+	`["a","b"`:                     `["a","b"]`,
+	`["a","b",`:                    `["a","b"]`,
+	`["a","b"]`:                    `["a","b"]`,
+	`["https://example.com/api/v1`: `["https://example.com/api/v1"]`,
+	`["\u0`:                        `[""]`,
+	`["\u00`:                       `[""]`,
+	`["\u004`:                      `[""]`,
+	`["\u0049`:                     `["\u0049"]`,
+	`["\u0049"`:                    `["\u0049"]`,
+	`["\u0049",`:                   `["\u0049"]`,
+	`["\u0049","`:                  `["\u0049",""]`,
+	`["\u0049","\`:                 `["\u0049",""]`,
+	`["\u0049","\u`:                `["\u0049",""]`,
+	`["\u0049","\u0`:               `["\u0049",""]`,
+	`["\u0049","\u00`:              `["\u0049",""]`,
+	`["\u0049","\u005`:             `["\u0049",""]`,
+	`["https://example.com/api/v1", "http://example.com:8080/user/sample_user/index.html`: `["https://example.com/api/v1", "http://example.com:8080/user/sample_user/index.html"]`,
+	`["\u0049","\u0050`:   `["\u0049","\u0050"]`,
+	`["\u0049","\u0050"`:  `["\u0049","\u0050"]`,
+	`["\u0049","\u0050"]`: `["\u0049","\u0050"]`,
+	`["\u0123`:            `["\u0123"]`,
+	`["\u4567`:            `["\u4567"]`,
+	`["\u89ab`:            `["\u89ab"]`,
+	`["\u89AB`:            `["\u89AB"]`,
+	`["\ucdef`:            `["\ucdef"]`,
+	`["\uCDEF`:            `["\uCDEF"]`,
 
-		// test case: object as array element
-		`[{`:                         `[{}]`,
-		`[{"`:                        `[{"":null}]`,
-		`[{""`:                       `[{"":null}]`,
-		`[{"":`:                      `[{"":null}]`,
-		`[{"":"`:                     `[{"":""}]`,
-		`[{"":""`:                    `[{"":""}]`,
-		`[{"":""}`:                   `[{"":""}]`,
-		`[{"":""}]`:                  `[{"":""}]`,
-		`[{"a`:                       `[{"a":null}]`,
-		`[{"a"`:                      `[{"a":null}]`,
-		`[{"a":`:                     `[{"a":null}]`,
-		`[{"a":"`:                    `[{"a":""}]`,
-		`[{"a":"b`:                   `[{"a":"b"}]`,
-		`[{"a":"b"`:                  `[{"a":"b"}]`,
-		`[{"a":"b"}`:                 `[{"a":"b"}]`,
-		`[{"a":"b"}]`:                `[{"a":"b"}]`,
-		`[{"a":n`:                    `[{"a":null}]`,
-		`[{"a":nu`:                   `[{"a":null}]`,
-		`[{"a":nul`:                  `[{"a":null}]`,
-		`[{"a":null`:                 `[{"a":null}]`,
-		`[{"a":null,`:                `[{"a":null}]`,
-		`[{"a":null}`:                `[{"a":null}]`,
-		`[{"a":null}]`:               `[{"a":null}]`,
-		`[{"a":t`:                    `[{"a":true}]`,
-		`[{"a":tr`:                   `[{"a":true}]`,
-		`[{"a":tru`:                  `[{"a":true}]`,
-		`[{"a":true`:                 `[{"a":true}]`,
-		`[{"a":true,`:                `[{"a":true}]`,
-		`[{"a":true}`:                `[{"a":true}]`,
-		`[{"a":true}]`:               `[{"a":true}]`,
-		`[{"a":f`:                    `[{"a":false}]`,
-		`[{"a":fa`:                   `[{"a":false}]`,
-		`[{"a":fal`:                  `[{"a":false}]`,
-		`[{"a":fals`:                 `[{"a":false}]`,
-		`[{"a":false`:                `[{"a":false}]`,
-		`[{"a":false,`:               `[{"a":false}]`,
-		`[{"a":false}`:               `[{"a":false}]`,
-		`[{"a":false}]`:              `[{"a":false}]`,
-		`[{"a":-`:                    `[{"a":0}]`,
-		`[{"a":0`:                    `[{"a":0}]`,
-		`[{"a":-0`:                   `[{"a":-0}]`, // @TODO: should be 0, not -0
-		`[{"a":0.`:                   `[{"a":0.0}]`,
-		`[{"a":0.1`:                  `[{"a":0.1}]`,
-		`[{"a":0.10`:                 `[{"a":0.10}]`,
-		`[{"a":0.10,`:                `[{"a":0.10}]`,
-		`[{"a":0.10}`:                `[{"a":0.10}]`,
-		`[{"a":0.10}]`:               `[{"a":0.10}]`,
-		`[{"a":-0.10}]`:              `[{"a":-0.10}]`,
-		`[{"a":[`:                    `[{"a":[]}]`,
-		`[{"a":[1`:                   `[{"a":[1]}]`,
-		`[{"a":[t`:                   `[{"a":[true]}]`,
-		`[{"a":[f`:                   `[{"a":[false]}]`,
-		`[{"a":[n`:                   `[{"a":[null]}]`,
-		`[{"a":["`:                   `[{"a":[""]}]`,
-		`[{"a":[{`:                   `[{"a":[{}]}]`,
-		`[{"a":[{"b":"c"},{`:         `[{"a":[{"b":"c"},{}]}]`,
-		`[{"a":[{"b":"c"},{"`:        `[{"a":[{"b":"c"},{"":null}]}]`,
-		`[{"a":[{"b":"c"},{"d"`:      `[{"a":[{"b":"c"},{"d":null}]}]`,
-		`[{"a":[{"b":"c"},{"d":-`:    `[{"a":[{"b":"c"},{"d":0}]}]`,
-		`[{"a":[{"b":"c"},{"d":-0`:   `[{"a":[{"b":"c"},{"d":-0}]}]`, // @TODO: should be 0, not -0
-		`[{"a":[{"b":"c"},{"d":1.`:   `[{"a":[{"b":"c"},{"d":1.0}]}]`,
-		`[{"a":[{"b":"c"},{"d":1.1`:  `[{"a":[{"b":"c"},{"d":1.1}]}]`,
-		`[{"a":[{"b":"c"},{"d":-1.1`: `[{"a":[{"b":"c"},{"d":-1.1}]}]`,
-		`[{"a":[{"b":"c"},{"d":[`:    `[{"a":[{"b":"c"},{"d":[]}]}]`,
-		`[{"a":[{"b":"c"},{"d":[{`:   `[{"a":[{"b":"c"},{"d":[{}]}]}]`,
+	// test case: object as array element
+	`[{`:                         `[{}]`,
+	`[{"`:                        `[{"":null}]`,
+	`[{""`:                       `[{"":null}]`,
+	`[{"":`:                      `[{"":null}]`,
+	`[{"":"`:                     `[{"":""}]`,
+	`[{"":""`:                    `[{"":""}]`,
+	`[{"":""}`:                   `[{"":""}]`,
+	`[{"":""}]`:                  `[{"":""}]`,
+	`[{"a`:                       `[{"a":null}]`,
+	`[{"a"`:                      `[{"a":null}]`,
+	`[{"a":`:                     `[{"a":null}]`,
+	`[{"a":"`:                    `[{"a":""}]`,
+	`[{"a":"b`:                   `[{"a":"b"}]`,
+	`[{"a":"b"`:                  `[{"a":"b"}]`,
+	`[{"a":"b"}`:                 `[{"a":"b"}]`,
+	`[{"a":"b"}]`:                `[{"a":"b"}]`,
+	`[{"a":n`:                    `[{"a":null}]`,
+	`[{"a":nu`:                   `[{"a":null}]`,
+	`[{"a":nul`:                  `[{"a":null}]`,
+	`[{"a":null`:                 `[{"a":null}]`,
+	`[{"a":null,`:                `[{"a":null}]`,
+	`[{"a":null}`:                `[{"a":null}]`,
+	`[{"a":null}]`:               `[{"a":null}]`,
+	`[{"a":t`:                    `[{"a":true}]`,
+	`[{"a":tr`:                   `[{"a":true}]`,
+	`[{"a":tru`:                  `[{"a":true}]`,
+	`[{"a":true`:                 `[{"a":true}]`,
+	`[{"a":true,`:                `[{"a":true}]`,
+	`[{"a":true}`:                `[{"a":true}]`,
+	`[{"a":true}]`:               `[{"a":true}]`,
+	`[{"a":f`:                    `[{"a":false}]`,
+	`[{"a":fa`:                   `[{"a":false}]`,
+	`[{"a":fal`:                  `[{"a":false}]`,
+	`[{"a":fals`:                 `[{"a":false}]`,
+	`[{"a":false`:                `[{"a":false}]`,
+	`[{"a":false,`:               `[{"a":false}]`,
+	`[{"a":false}`:               `[{"a":false}]`,
+	`[{"a":false}]`:              `[{"a":false}]`,
+	`[{"a":-`:                    `[{"a":0}]`,
+	`[{"a":0`:                    `[{"a":0}]`,
+	`[{"a":-0`:                   `[{"a":-0}]`,
+	`[{"a":0.`:                   `[{"a":0}]`,
+	`[{"a":0.1`:                  `[{"a":0.1}]`,
+	`[{"a":0.10`:                 `[{"a":0.10}]`,
+	`[{"a":0.10,`:                `[{"a":0.10}]`,
+	`[{"a":0.10}`:                `[{"a":0.10}]`,
+	`[{"a":0.10}]`:               `[{"a":0.10}]`,
+	`[{"a":-0.10}]`:              `[{"a":-0.10}]`,
+	`[{"a":[`:                    `[{"a":[]}]`,
+	`[{"a":[1`:                   `[{"a":[1]}]`,
+	`[{"a":[t`:                   `[{"a":[true]}]`,
+	`[{"a":[f`:                   `[{"a":[false]}]`,
+	`[{"a":[n`:                   `[{"a":[null]}]`,
+	`[{"a":["`:                   `[{"a":[""]}]`,
+	`[{"a":[{`:                   `[{"a":[{}]}]`,
+	`[{"a":[{"b":"c"},{`:         `[{"a":[{"b":"c"},{}]}]`,
+	`[{"a":[{"b":"c"},{"`:        `[{"a":[{"b":"c"},{"":null}]}]`,
+	`[{"a":[{"b":"c"},{"d"`:      `[{"a":[{"b":"c"},{"d":null}]}]`,
+	`[{"a":[{"b":"c"},{"d":-`:    `[{"a":[{"b":"c"},{"d":0}]}]`,
+	`[{"a":[{"b":"c"},{"d":-0`:   `[{"a":[{"b":"c"},{"d":-0}]}]`,
+	`[{"a":[{"b":"c"},{"d":1.`:   `[{"a":[{"b":"c"},{"d":1}]}]`,
+	`[{"a":[{"b":"c"},{"d":1.1`:  `[{"a":[{"b":"c"},{"d":1.1}]}]`,
+	`[{"a":[{"b":"c"},{"d":-1.1`: `[{"a":[{"b":"c"},{"d":-1.1}]}]`,
+	`[{"a":[{"b":"c"},{"d":[`:    `[{"a":[{"b":"c"},{"d":[]}]}]`,
+	`[{"a":[{"b":"c"},{"d":[{`:   `[{"a":[{"b":"c"},{"d":[{}]}]}]`,
 
-		// test case: multiple array element
-		`[1,1.20,0.03,-1,-1.20,-0.03,1.997e3,-1.338e19,"a",null,true,false,{},[]]`: `[1,1.20,0.03,-1,-1.20,-0.03,1.997e3,-1.338e19,"a",null,true,false,{},[]]`,
+	// test case: multiple array element
+	`[1,1.20,0.03,-1,-1.20,-0.03,1.997e3,-1.338e19,"a",null,true,false,{},[]]`: `[1,1.20,0.03,-1,-1.20,-0.03,1.997e3,-1.338e19,"a",null,true,false,{},[]]`,
 
-		// test case: array as array element
-		`[[`:                `[[]]`,
-		`[[]`:               `[[]]`,
-		`[[]]`:              `[[]]`,
-		`[[{`:               `[[{}]]`,
-		`[["`:               `[[""]]`,
-		`[[""`:              `[[""]]`,
-		`[["a`:              `[["a"]]`,
-		`[["a"`:             `[["a"]]`,
-		`[["a"]`:            `[["a"]]`,
-		`[["a"],`:           `[["a"]]`,
-		`[["a"],[`:          `[["a"],[]]`,
-		`[["a"],[]`:         `[["a"],[]]`,
-		`[["a"],[]]`:        `[["a"],[]]`,
-		`[["a"],{`:          `[["a"],{}]`,
-		`[["a"],{}`:         `[["a"],{}]`,
-		`[["a"],{}]`:        `[["a"],{}]`,
-		`[["a"],{"`:         `[["a"],{"":null}]`,
-		`[["a"],{"b`:        `[["a"],{"b":null}]`,
-		`[["a"],{"b"`:       `[["a"],{"b":null}]`,
-		`[["a"],{"b":`:      `[["a"],{"b":null}]`,
-		`[["a"],{"b":"`:     `[["a"],{"b":""}]`,
-		`[["a"],{"b":"c`:    `[["a"],{"b":"c"}]`,
-		`[["a"],{"b":"c"`:   `[["a"],{"b":"c"}]`,
-		`[["a"],{"b":"c"}`:  `[["a"],{"b":"c"}]`,
-		`[["a"],{"b":"c"}]`: `[["a"],{"b":"c"}]`,
+	// test case: array as array element
+	`[[`:                `[[]]`,
+	`[[]`:               `[[]]`,
+	`[[]]`:              `[[]]`,
+	`[[{`:               `[[{}]]`,
+	`[["`:               `[[""]]`,
+	`[[""`:              `[[""]]`,
+	`[["a`:              `[["a"]]`,
+	`[["a"`:             `[["a"]]`,
+	`[["a"]`:            `[["a"]]`,
+	`[["a"],`:           `[["a"]]`,
+	`[["a"],[`:          `[["a"],[]]`,
+	`[["a"],[]`:         `[["a"],[]]`,
+	`[["a"],[]]`:        `[["a"],[]]`,
+	`[["a"],{`:          `[["a"],{}]`,
+	`[["a"],{}`:         `[["a"],{}]`,
+	`[["a"],{}]`:        `[["a"],{}]`,
+	`[["a"],{"`:         `[["a"],{"":null}]`,
+	`[["a"],{"b`:        `[["a"],{"b":null}]`,
+	`[["a"],{"b"`:       `[["a"],{"b":null}]`,
+	`[["a"],{"b":`:      `[["a"],{"b":null}]`,
+	`[["a"],{"b":"`:     `[["a"],{"b":""}]`,
+	`[["a"],{"b":"c`:    `[["a"],{"b":"c"}]`,
+	`[["a"],{"b":"c"`:   `[["a"],{"b":"c"}]`,
+	`[["a"],{"b":"c"}`:  `[["a"],{"b":"c"}]`,
+	`[["a"],{"b":"c"}]`: `[["a"],{"b":"c"}]`,
 
-		// test case: ignore token
-		`{ }`:                                  `{ }`,
-		`{ " a " : -1.2 , `:                    `{ " a " : -1.2}`,
-		`{ " a " : -1.2 , "  b  "  :  " c "  `: `{ " a " : -1.2 , "  b  "  :  " c "}`,
-		`{ " a " : -1.2 , "  b  "  :  " c "   , "   d"  :  true  `:                    `{ " a " : -1.2 , "  b  "  :  " c "   , "   d"  :  true}`,
-		`{ " a " : -1.2 , "  b  "  :  " c "   , "   d"  :  true  , "e   "  : {  } } `: `{ " a " : -1.2 , "  b  "  :  " c "   , "   d"  :  true  , "e   "  : {  } }`,
-		`[ ]`:                                  `[ ]`,
-		`[ 1`:                                  `[ 1]`,
-		`[ 1 , -1.020  , true ,  false,  null`: `[ 1 , -1.020  , true ,  false,  null]`,
-		`[ 1 , -1.020  , true ,  false,  null,  {   }`: `[ 1 , -1.020  , true ,  false,  null,  {   }]`,
-	}
+	// test case: string as root value
+	`"`:       `""`,
+	`"a`:      `"a"`,
+	`"a\`:     `"a"`,
+	`"a\u00`:  `"a"`,
+	`"a\"b"`:  `"a\"b"`,
+	` "a b" `: ` "a b"`,
+
+	// test case: ignore token
+	`{ }`:                                  `{ }`,
+	`{ " a " : -1.2 , `:                    `{ " a " : -1.2}`,
+	`{ " a " : -1.2 , "  b  "  :  " c "  `: `{ " a " : -1.2 , "  b  "  :  " c "}`,
+	`{ " a " : -1.2 , "  b  "  :  " c "   , "   d"  :  true  `:                    `{ " a " : -1.2 , "  b  "  :  " c "   , "   d"  :  true}`,
+	`{ " a " : -1.2 , "  b  "  :  " c "   , "   d"  :  true  , "e   "  : {  } } `: `{ " a " : -1.2 , "  b  "  :  " c "   , "   d"  :  true  , "e   "  : {  } }`,
+	`[ ]`:                                  `[ ]`,
+	`[ 1`:                                  `[ 1]`,
+	`[ 1 , -1.020  , true ,  false,  null`: `[ 1 , -1.020  , true ,  false,  null]`,
+	`[ 1 , -1.020  , true ,  false,  null,  {   }`: `[ 1 , -1.020  , true ,  false,  null,  {   }]`,
+}
+
+func TestCompleteJSON_base(t *testing.T) {
+	streamingJSONCase := completeJSONBaseCases
 	for testCase, expect := range streamingJSONCase {
 		lexer := NewLexer()
 		errInAppendString := lexer.AppendString(testCase)
@@ -486,8 +506,10 @@ func TestCompleteJSON_base(t *testing.T) {
 	}
 }
 
+var nestedJSONDocument = `{"string": "这是一个字符串", "integer": 42, "float": 3.14159, "boolean_true": true, "boolean_false": false, "null": null, "object": {"empty_object": {}, "non_empty_object": {"key": "value"}, "nested_object": {"nested_key": {"sub_nested_key": "sub_nested_value"}}}, "array":["string in array", 123, 45.67, true, false, null, {"object_in_array": "object_value"},["nested_array"]]}`
+
 func TestCompleteJSON_nestad(t *testing.T) {
-	streamingJSONContent := `{"string": "这是一个字符串", "integer": 42, "float": 3.14159, "boolean_true": true, "boolean_false": false, "null": null, "object": {"empty_object": {}, "non_empty_object": {"key": "value"}, "nested_object": {"nested_key": {"sub_nested_key": "sub_nested_value"}}}, "array":["string in array", 123, 45.67, true, false, null, {"object_in_array": "object_value"},["nested_array"]]}`
+	streamingJSONContent := nestedJSONDocument
 	lexer := NewLexer()
 	for _, char := range streamingJSONContent {
 		errInAppendString := lexer.AppendString(string(char))
@@ -500,8 +522,7 @@ func TestCompleteJSON_nestad(t *testing.T) {
 
 }
 
-func TestCompleteJSON_nestad2(t *testing.T) {
-	streamingJSONContent := `{
+var nestedJSONDocument2 = `{
     "string_with_escape_chars": "This string contains escape characters like \"quotes\", \\backslashes\\, \/forwardslashes/, \bbackspace\b, \fformfeed\f, \nnewline\n, \rcarriage return\r, \ttab\t.",
     "scientific_notation": 2.998e8,
     "unicode_characters": "Some unicode characters: \u0041\u0042\u0043\u0044",
@@ -550,6 +571,9 @@ func TestCompleteJSON_nestad2(t *testing.T) {
         "empty_arrays": []
     }
 }`
+
+func TestCompleteJSON_nestad2(t *testing.T) {
+	streamingJSONContent := nestedJSONDocument2
 	lexer := NewLexer()
 	var expectContent strings.Builder
 	for _, char := range streamingJSONContent {
@@ -563,8 +587,7 @@ func TestCompleteJSON_nestad2(t *testing.T) {
 	}
 }
 
-func TestCompleteJSON_escapeAndEtc(t *testing.T) {
-	streamingJSONContent := `{
+var escapedJSONDocument = `{
   "string": "含有转义字符的字符串：\"\\\/\b\f\n\r\t",
   "string_unicode": "含Unicode字符：\u6211\u662F",
   "negative_integer": -42,
@@ -587,6 +610,9 @@ func TestCompleteJSON_escapeAndEtc(t *testing.T) {
     }
   }
 }`
+
+func TestCompleteJSON_escapeAndEtc(t *testing.T) {
+	streamingJSONContent := escapedJSONDocument
 	lexer := NewLexer()
 	var expectContent strings.Builder
 	for _, char := range streamingJSONContent {
@@ -600,3 +626,73 @@ func TestCompleteJSON_escapeAndEtc(t *testing.T) {
 	}
 
 }
+
+func TestAppendString_zeroAllocations(t *testing.T) {
+	segment := `{"string": "value \"escaped\" 你", "number": -3.14e-2, "literals": [true, false, null], "object": {"empty": {}}},`
+	lexer := NewLexer()
+	lexer.JSONContent.Grow(1024 * len(segment))
+	assert.Nil(t, lexer.AppendString("["+segment))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := lexer.AppendString(segment); err != nil {
+			t.Fatal(err)
+		}
+	})
+	assert.Equal(t, float64(0), allocs)
+
+	// split into small chunks
+	var chunks []string
+	for i := 0; i < len(segment); i += 7 {
+		chunkEnd := i + 7
+		if chunkEnd > len(segment) {
+			chunkEnd = len(segment)
+		}
+		chunks = append(chunks, segment[i:chunkEnd])
+	}
+	// PaddingContent is copied only if the padding content after chunk changes
+	paddingChanges := 0
+	for _, chunk := range chunks {
+		paddingContent := lexer.PaddingContent.String()
+		assert.Nil(t, lexer.AppendString(chunk))
+		if lexer.PaddingContent.String() != paddingContent && lexer.PaddingContent.Len() > 0 {
+			paddingChanges++
+		}
+	}
+	allocs = testing.AllocsPerRun(100, func() {
+		for _, chunk := range chunks {
+			if err := lexer.AppendString(chunk); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+	assert.Equal(t, float64(paddingChanges), allocs)
+}
+
+func TestCompleteJSON_longString(t *testing.T) {
+	streamingJSONContent := `{"content": "package main\n\nfunc main() {\n\tfmt.Println(\"hello \\ world \/ 你好\")\n}\n", "next": ["\b\f\r", "\u4f60\"", ""]}`
+	for chunkSize := 1; chunkSize <= len(streamingJSONContent); chunkSize++ {
+		lexer := NewLexer()
+		byteLexer := NewLexer()
+		for i := 0; i < len(streamingJSONContent); i += chunkSize {
+			chunkEnd := i + chunkSize
+			if chunkEnd > len(streamingJSONContent) {
+				chunkEnd = len(streamingJSONContent)
+			}
+			assert.Nil(t, lexer.AppendString(streamingJSONContent[i:chunkEnd]))
+			for j := i; j < chunkEnd; j++ {
+				assert.Nil(t, byteLexer.AppendString(streamingJSONContent[j:j+1]))
+			}
+			assert.Equal(t, byteLexer.CompleteJSON(), lexer.CompleteJSON(), "unexpected completed JSON for chunk size %d", chunkSize)
+		}
+		assert.Equal(t, streamingJSONContent, lexer.CompleteJSON())
+	}
+}
+
+func TestAppendString_exportedStacks(t *testing.T) {
+	lexer := NewLexer()
+	assert.Nil(t, lexer.AppendString(`{"a": [1 ,`))
+	assert.Equal(t, " ,", lexer.PaddingContent.String())
+	assert.Equal(t, []int{TOKEN_LEFT_BRACE, TOKEN_QUOTE, TOKEN_QUOTE, TOKEN_COLON, TOKEN_LEFT_BRACKET, TOKEN_NUMBER, TOKEN_COMMA}, lexer.TokenStack)
+	assert.Nil(t, lexer.AppendString(` 2`))
+	assert.Equal(t, "", lexer.PaddingContent.String())
+}
@@ -1,7 +1,9 @@
 package streamingjsongo
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -13,7 +15,9 @@ func BenchmarkParse(b *testing.B) {
 	b.Run("streaming-json-go-append-and-complete-json-segment", func(b *testing.B) {
 		benchmarkAppendAndCompleteJSON(b, testCaseA)
 	})
-
+	b.Run("streaming-json-go-append-json-segment-steady-state", func(b *testing.B) {
+		benchmarkAppendStringSteadyState(b, testCaseA)
+	})
 }
 
 func benchmarkAppendString(b *testing.B, s string) {
@@ -46,3 +50,245 @@ func benchmarkAppendAndCompleteJSON(b *testing.B, s string) {
 		}
 	})
 }
+
+// append JSON segment as element of a long-lived JSON array stream, the lexer is renewed every 1024 segments to bound memory
+func benchmarkAppendStringSteadyState(b *testing.B, s string) {
+	const segmentsPerLexer = 1024
+	b.ReportAllocs()
+	b.SetBytes(int64(len(s)))
+	segment := s + ","
+	b.RunParallel(func(pb *testing.PB) {
+		var lexer *Lexer
+		for i := 0; pb.Next(); i++ {
+			if i%segmentsPerLexer == 0 {
+				lexer = NewLexer()
+				lexer.JSONContent.Grow(segmentsPerLexer*len(segment) + 1)
+				lexer.AppendString("[")
+			}
+			if err := lexer.AppendString(segment); err != nil {
+				panic(fmt.Errorf("unexpected error: %s", err))
+			}
+		}
+	})
+}
+
+func BenchmarkParseLongString(b *testing.B) {
+	// tool call arguments with source code in string, like LLM output
+	var code strings.Builder
+	for code.Len() < 16*1024 {
+		code.WriteString(`func (lexer *Lexer) AppendString(str string) error {\n\t// append \"str\" to the stream\n\treturn lexer.appendString(str)\n}\n\n`)
+	}
+	testCaseB := `{"name": "write_file", "arguments": {"path": "lexer.go", "content": "` + code.String() + `"}}`
+	b.Run("streaming-json-go-append-json-segment", func(b *testing.B) {
+		benchmarkAppendString(b, testCaseB)
+	})
+	b.Run("streaming-json-go-append-json-chunks", func(b *testing.B) {
+		benchmarkAppendStringChunks(b, testCaseB, 16)
+	})
+}
+
+// append JSON in chunks of given size, like tokens streamed from LLM
+func benchmarkAppendStringChunks(b *testing.B, s string, chunkSize int) {
+	chunks := splitIntoChunks(s, chunkSize)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(s)))
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			lexer := NewLexer()
+			for _, chunk := range chunks {
+				if err := lexer.AppendString(chunk); err != nil {
+					panic(fmt.Errorf("unexpected error: %s", err))
+				}
+			}
+		}
+	})
+}
+
+// JSON documents of LLM output shapes for streaming workload benchmark, 2KB to 4KB except mixed
+var streamingWorkloadDocuments = []struct {
+	shape    string
+	document string
+}{
+	{"mixed", `{"string": "这是一个字符串", "integer": 42, "float": 3.14159, "boolean_true": true, "boolean_false": false, "null": null, "object": {"empty_object": {}, "non_empty_object": {"key": "value"}, "nested_object": {"nested_key": {"sub_nested_key": "sub_nested_value"}}}, "array":["string in array", 123, 45.67, true, false, null, {"object_in_array": "object_value"},["nested_array"]]}`},
+	{"deep-nesting", newDeepNestingJSONDocument(64)},
+	{"wide-object", newWideObjectJSONDocument(96)},
+	{"long-string", newLongStringJSONDocument(4 * 1024)},
+	{"numeric-array", newNumericArrayJSONDocument(256)},
+}
+
+// new JSON document with objects and arrays nested in depth, like `{"a":[{"a":[...]}]}`
+func newDeepNestingJSONDocument(depth int) string {
+	var document strings.Builder
+	for i := 0; i < depth; i++ {
+		fmt.Fprintf(&document, `{"level": %d, "children": [`, i)
+	}
+	document.WriteString(`"leaf"`)
+	for i := 0; i < depth; i++ {
+		document.WriteString(`]}`)
+	}
+	return document.String()
+}
+
+// new JSON document of object with many properties
+func newWideObjectJSONDocument(properties int) string {
+	var document strings.Builder
+	document.WriteByte('{')
+	for i := 0; i < properties; i++ {
+		if i > 0 {
+			document.WriteString(", ")
+		}
+		fmt.Fprintf(&document, `"field_%d": "value %d"`, i, i)
+	}
+	document.WriteByte('}')
+	return document.String()
+}
+
+// new JSON document of tool call arguments with source code in string
+func newLongStringJSONDocument(length int) string {
+	var code strings.Builder
+	for code.Len() < length {
+		code.WriteString(`func (lexer *Lexer) AppendString(str string) error {\n\t// append \"str\" to the stream\n\treturn lexer.appendString(str)\n}\n\n`)
+	}
+	return `{"name": "write_file", "arguments": {"path": "lexer.go", "content": "` + code.String() + `"}}`
+}
+
+// new JSON document of array with numbers, like embeddings
+func newNumericArrayJSONDocument(elements int) string {
+	var document strings.Builder
+	document.WriteString(`{"embedding": [`)
+	for i := 0; i < elements; i++ {
+		if i > 0 {
+			document.WriteString(", ")
+		}
+		fmt.Fprintf(&document, "%.4f", float64(i%97)/97-0.5)
+	}
+	document.WriteString(`]}`)
+	return document.String()
+}
+
+// split JSON stream into chunks of given size
+func splitIntoChunks(s string, chunkSize int) []string {
+	var chunks []string
+	for i := 0; i < len(s); i += chunkSize {
+		chunkEnd := i + chunkSize
+		if chunkEnd > len(s) {
+			chunkEnd = len(s)
+		}
+		chunks = append(chunks, s[i:chunkEnd])
+	}
+	return chunks
+}
+
+// streaming workload of LLM output: JSON documents in shapes arrive in small chunks, and the JSON is consumed after each chunk.
+// the approaches are:
+//   - streaming-json-go-append: AppendString() for each chunk, and CompleteJSON() only at the end
+//   - streaming-json-go: AppendString() and CompleteJSON() for each chunk
+//   - streaming-json-go-decode: and decode completed JSON by encoding/json
+//   - reparse-encoding-json: decode all received JSON by encoding/json for each chunk, which succeeds only when JSON is complete
+//   - reparse-complete-encoding-json: complete all received JSON with a new lexer and decode it by encoding/json for each chunk
+//
+// the sub-benchmark names are in `key=value` form, compare the approaches by `benchstat -col /approach`.
+func BenchmarkStreamingWorkload(b *testing.B) {
+	for _, testCase := range streamingWorkloadDocuments {
+		if !json.Valid([]byte(testCase.document)) {
+			b.Fatalf("invalid JSON document of shape %s", testCase.shape)
+		}
+		for _, chunkSize := range []int{1, 4, 16, 64} {
+			chunks := splitIntoChunks(testCase.document, chunkSize)
+			prefix := fmt.Sprintf("shape=%s/chunk=%d/approach=", testCase.shape, chunkSize)
+			b.Run(prefix+"streaming-json-go-append", func(b *testing.B) {
+				benchmarkStreamingWorkload(b, testCase.document, chunks, consumeByStreamingJSONAppend)
+			})
+			b.Run(prefix+"streaming-json-go", func(b *testing.B) {
+				benchmarkStreamingWorkload(b, testCase.document, chunks, consumeByStreamingJSON)
+			})
+			b.Run(prefix+"streaming-json-go-decode", func(b *testing.B) {
+				benchmarkStreamingWorkload(b, testCase.document, chunks, consumeByStreamingJSONAndDecode)
+			})
+			b.Run(prefix+"reparse-encoding-json", func(b *testing.B) {
+				benchmarkStreamingWorkload(b, testCase.document, chunks, consumeByReparse)
+			})
+			b.Run(prefix+"reparse-complete-encoding-json", func(b *testing.B) {
+				benchmarkStreamingWorkload(b, testCase.document, chunks, consumeByReparseAndComplete)
+			})
+		}
+	}
+}
+
+// consume JSON stream chunk by chunk, returns the final JSON value in any form
+type streamingWorkloadConsumer func(chunks []string) interface{}
+
+func benchmarkStreamingWorkload(b *testing.B, document string, chunks []string, consume streamingWorkloadConsumer) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(document)))
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if consume(chunks) == nil {
+				panic(fmt.Errorf("JSON stream is not consumed"))
+			}
+		}
+	})
+}
+
+func consumeByStreamingJSONAppend(chunks []string) interface{} {
+	lexer := NewLexer()
+	for _, chunk := range chunks {
+		if err := lexer.AppendString(chunk); err != nil {
+			panic(fmt.Errorf("unexpected error: %s", err))
+		}
+	}
+	return lexer.CompleteJSON()
+}
+
+func consumeByStreamingJSON(chunks []string) interface{} {
+	lexer := NewLexer()
+	var completedJSON string
+	for _, chunk := range chunks {
+		if err := lexer.AppendString(chunk); err != nil {
+			panic(fmt.Errorf("unexpected error: %s", err))
+		}
+		completedJSON = lexer.CompleteJSON()
+	}
+	return completedJSON
+}
+
+func consumeByStreamingJSONAndDecode(chunks []string) interface{} {
+	lexer := NewLexer()
+	var value interface{}
+	for _, chunk := range chunks {
+		if err := lexer.AppendString(chunk); err != nil {
+			panic(fmt.Errorf("unexpected error: %s", err))
+		}
+		if err := json.Unmarshal([]byte(lexer.CompleteJSON()), &value); err != nil {
+			panic(fmt.Errorf("unexpected error: %s", err))
+		}
+	}
+	return value
+}
+
+func consumeByReparse(chunks []string) interface{} {
+	var received []byte
+	var value interface{}
+	for _, chunk := range chunks {
+		received = append(received, chunk...)
+		// incomplete JSON is an error, wait for more
+		json.Unmarshal(received, &value)
+	}
+	return value
+}
+
+func consumeByReparseAndComplete(chunks []string) interface{} {
+	var received strings.Builder
+	var value interface{}
+	for _, chunk := range chunks {
+		received.WriteString(chunk)
+		lexer := NewLexer()
+		if err := lexer.AppendString(received.String()); err != nil {
+			panic(fmt.Errorf("unexpected error: %s", err))
+		}
+		if err := json.Unmarshal([]byte(lexer.CompleteJSON()), &value); err != nil {
+			panic(fmt.Errorf("unexpected error: %s", err))
+		}
+	}
+	return value
+}
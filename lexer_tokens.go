@@ -138,3 +138,20 @@ var tokenSymbolMap = map[int]string{
 	TOKEN_NUMBER_8:             "8",
 	TOKEN_NUMBER_9:             "9",
 }
+
+var tokenNameMap = map[int]string{
+	TOKEN_IGNORED: "IGNORED",
+	TOKEN_NUMBER:  "NUMBER",
+	TOKEN_OTHERS:  "OTHERS",
+}
+
+// get readable name of token, for debugging
+func TokenName(token int) string {
+	if name, ok := tokenNameMap[token]; ok {
+		return name
+	}
+	if symbol, ok := tokenSymbolMap[token]; ok {
+		return symbol
+	}
+	return "UNKNOWN"
+}
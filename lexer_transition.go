@@ -0,0 +1,194 @@
+package streamingjsongo
+
+// state of JSON stream cursor, the lexer moves between states by byte class of next byte
+const (
+	STREAM_STATE_VALUE                 = iota // root value expected, like `` or after the root value
+	STREAM_STATE_ARRAY_VALUE                  // array element or `]` expected, like `[` or `[1,`
+	STREAM_STATE_OBJECT_KEY                   // properity key or `}` expected, like `{` or `{"a":1,`
+	STREAM_STATE_KEY                          // in properity key, like `{"a`
+	STREAM_STATE_KEY_ESCAPE                   // after escape character in properity key, like `{"\`
+	STREAM_STATE_KEY_UNICODE_ESCAPE           // in unicode escape of properity key, like `{"\u00`
+	STREAM_STATE_COLON                        // `:` expected after properity key, like `{"a"`
+	STREAM_STATE_OBJECT_VALUE                 // properity value expected, like `{"a":`
+	STREAM_STATE_STRING                       // in string value, like `["a`
+	STREAM_STATE_STRING_ESCAPE                // after escape character in string value, like `["\`
+	STREAM_STATE_STRING_UNICODE_ESCAPE        // in unicode escape of string value, like `["\u00`
+	STREAM_STATE_LITERAL                      // in literal, the rest of literal is in mirror stack, like `[tr`
+	STREAM_STATE_NUMBER                       // in number, the step of number is in number state, like `[-1.2e`
+	STREAM_STATE_ARRAY_VALUE_END              // `,` or `]` expected after array element, like `[1`
+	STREAM_STATE_OBJECT_VALUE_END             // `,` or `}` expected after properity value, like `{"a":1`
+	STREAM_STATE_COUNT
+)
+
+// class of byte in JSON stream, the bytes of the same class move the lexer in the same way in every state
+const (
+	BYTE_CLASS_OTHERS                    = iota // anything else in JSON stream
+	BYTE_CLASS_WHITESPACE                       // '\t', '\n', '\v', '\f', '\r', ' '
+	BYTE_CLASS_QUOTE                            // "
+	BYTE_CLASS_ESCAPE_CHARACTER                 // \
+	BYTE_CLASS_SLASH                            // /
+	BYTE_CLASS_LEFT_BRACE                       // {
+	BYTE_CLASS_RIGHT_BRACE                      // }
+	BYTE_CLASS_LEFT_BRACKET                     // [
+	BYTE_CLASS_RIGHT_BRACKET                    // ]
+	BYTE_CLASS_COLON                            // :
+	BYTE_CLASS_COMMA                            // ,
+	BYTE_CLASS_NEGATIVE                         // -
+	BYTE_CLASS_POSITIVE                         // +
+	BYTE_CLASS_DOT                              // .
+	BYTE_CLASS_DIGIT                            // 0-9
+	BYTE_CLASS_EXPONENT                         // e, E, exponent of number and hex digit
+	BYTE_CLASS_HEX_LETTER                       // a, c, d, A, B, C, D, F
+	BYTE_CLASS_ESCAPE_HEX_LETTER                // b, short escape and hex digit
+	BYTE_CLASS_ESCAPE_LITERAL_HEX_LETTER        // f, short escape, start of `false` and hex digit
+	BYTE_CLASS_ESCAPE_LITERAL_LETTER            // n, t, short escape and start of `null`, `true`
+	BYTE_CLASS_ESCAPE_LETTER                    // r, short escape
+	BYTE_CLASS_UNICODE_ESCAPE_LETTER            // u, start of unicode escape
+	BYTE_CLASS_COUNT
+)
+
+// transition of lexer by next byte in given state
+const (
+	TRANSITION_OTHERS               = iota // the byte out of JSON grammar is written into JSON content as it is
+	TRANSITION_PADDING                     // whitespace is kept in padding content until next token
+	TRANSITION_STRING_CONTENT              // string content until quote or escape character
+	TRANSITION_OPEN_OBJECT                 // `{` opens object
+	TRANSITION_OPEN_ARRAY                  // `[` opens array
+	TRANSITION_CLOSE                       // `}` or `]` closes container
+	TRANSITION_START_KEY                   // `"` starts properity key
+	TRANSITION_END_KEY                     // `"` ends properity key
+	TRANSITION_COLON                       // `:` after properity key
+	TRANSITION_COMMA                       // `,` after value is kept in padding content until next member
+	TRANSITION_START_STRING                // `"` starts string value
+	TRANSITION_END_STRING                  // `"` ends string value
+	TRANSITION_START_ESCAPE                // `\` starts escape sequence in string
+	TRANSITION_SHORT_ESCAPE                // escaped character of short escape sequence, like `n` of `\n`
+	TRANSITION_START_UNICODE_ESCAPE        // `u` of unicode escape sequence
+	TRANSITION_UNICODE_ESCAPE_HEX          // hex digit of unicode escape sequence
+	TRANSITION_DROP_ESCAPE                 // the invalid escape sequence is dropped with the byte, like `\x`
+	TRANSITION_DROP_UNICODE_ESCAPE         // the incomplete unicode escape sequence is dropped, and the byte is in string, like `\u0"`
+	TRANSITION_START_LITERAL               // `t`, `f`, `n` starts literal
+	TRANSITION_LITERAL                     // next letter of literal
+	TRANSITION_START_NUMBER                // `-` or digit starts number
+	TRANSITION_NUMBER                      // next byte of number
+	TRANSITION_END_NUMBER                  // the byte after number ends it, and the byte is after value
+	TRANSITION_DROP_NUMBER                 // the number after value without comma is dropped, like `2` of `[1 2`
+	TRANSITION_INVALID_QUOTE               // `"` out of place, the rest of JSON segment is dropped
+)
+
+// byte to byte class lookup table
+var byteClassTable = newByteClassTable()
+
+// state and byte class to transition lookup table
+var streamTransitionTable = newStreamTransitionTable()
+
+// new byte to byte class lookup table
+func newByteClassTable() [256]uint8 {
+	var table [256]uint8
+	for i := range table {
+		if isIgnoreToken(byte(i)) {
+			table[i] = BYTE_CLASS_WHITESPACE
+		}
+	}
+	for c := TOKEN_NUMBER_0_SYMBOL; c <= TOKEN_NUMBER_9_SYMBOL; c++ {
+		table[c] = BYTE_CLASS_DIGIT
+	}
+	classes := map[byte]uint8{
+		TOKEN_QUOTE_SYMBOL:            BYTE_CLASS_QUOTE,
+		TOKEN_ESCAPE_CHARACTER_SYMBOL: BYTE_CLASS_ESCAPE_CHARACTER,
+		TOKEN_SLASH_SYMBOL:            BYTE_CLASS_SLASH,
+		TOKEN_LEFT_BRACE_SYMBOL:       BYTE_CLASS_LEFT_BRACE,
+		TOKEN_RIGHT_BRACE_SYMBOL:      BYTE_CLASS_RIGHT_BRACE,
+		TOKEN_LEFT_BRACKET_SYMBOL:     BYTE_CLASS_LEFT_BRACKET,
+		TOKEN_RIGHT_BRACKET_SYMBOL:    BYTE_CLASS_RIGHT_BRACKET,
+		TOKEN_COLON_SYMBOL:            BYTE_CLASS_COLON,
+		TOKEN_COMMA_SYMBOL:            BYTE_CLASS_COMMA,
+		TOKEN_NEGATIVE_SYMBOL:         BYTE_CLASS_NEGATIVE,
+		'+':                           BYTE_CLASS_POSITIVE,
+		TOKEN_DOT_SYMBOL:              BYTE_CLASS_DOT,
+		'e':                           BYTE_CLASS_EXPONENT,
+		'E':                           BYTE_CLASS_EXPONENT,
+		'a':                           BYTE_CLASS_HEX_LETTER,
+		'c':                           BYTE_CLASS_HEX_LETTER,
+		'd':                           BYTE_CLASS_HEX_LETTER,
+		'A':                           BYTE_CLASS_HEX_LETTER,
+		'B':                           BYTE_CLASS_HEX_LETTER,
+		'C':                           BYTE_CLASS_HEX_LETTER,
+		'D':                           BYTE_CLASS_HEX_LETTER,
+		'F':                           BYTE_CLASS_HEX_LETTER,
+		'b':                           BYTE_CLASS_ESCAPE_HEX_LETTER,
+		'f':                           BYTE_CLASS_ESCAPE_LITERAL_HEX_LETTER,
+		'n':                           BYTE_CLASS_ESCAPE_LITERAL_LETTER,
+		't':                           BYTE_CLASS_ESCAPE_LITERAL_LETTER,
+		'r':                           BYTE_CLASS_ESCAPE_LETTER,
+		'u':                           BYTE_CLASS_UNICODE_ESCAPE_LETTER,
+	}
+	for c, class := range classes {
+		table[c] = class
+	}
+	return table
+}
+
+// new state and byte class to transition lookup table, the bytes without transition in a state are TRANSITION_OTHERS
+func newStreamTransitionTable() [STREAM_STATE_COUNT][BYTE_CLASS_COUNT]uint8 {
+	var table [STREAM_STATE_COUNT][BYTE_CLASS_COUNT]uint8
+	set := func(states []int, classes []int, transition uint8) {
+		for _, state := range states {
+			for _, class := range classes {
+				table[state][class] = transition
+			}
+		}
+	}
+	allClasses := make([]int, BYTE_CLASS_COUNT)
+	for class := range allClasses {
+		allClasses[class] = class
+	}
+	valueStates := []int{STREAM_STATE_VALUE, STREAM_STATE_ARRAY_VALUE, STREAM_STATE_OBJECT_VALUE}
+	stringStates := []int{STREAM_STATE_KEY, STREAM_STATE_STRING}
+	escapeStates := []int{STREAM_STATE_KEY_ESCAPE, STREAM_STATE_STRING_ESCAPE}
+	unicodeEscapeStates := []int{STREAM_STATE_KEY_UNICODE_ESCAPE, STREAM_STATE_STRING_UNICODE_ESCAPE}
+	valueEndStates := []int{STREAM_STATE_ARRAY_VALUE_END, STREAM_STATE_OBJECT_VALUE_END}
+	outOfStringStates := []int{STREAM_STATE_VALUE, STREAM_STATE_ARRAY_VALUE, STREAM_STATE_OBJECT_KEY, STREAM_STATE_COLON, STREAM_STATE_OBJECT_VALUE,
+		STREAM_STATE_LITERAL, STREAM_STATE_ARRAY_VALUE_END, STREAM_STATE_OBJECT_VALUE_END}
+	closers := []int{BYTE_CLASS_RIGHT_BRACE, BYTE_CLASS_RIGHT_BRACKET}
+	hexDigits := []int{BYTE_CLASS_DIGIT, BYTE_CLASS_EXPONENT, BYTE_CLASS_HEX_LETTER, BYTE_CLASS_ESCAPE_HEX_LETTER, BYTE_CLASS_ESCAPE_LITERAL_HEX_LETTER}
+	numberBytes := []int{BYTE_CLASS_NEGATIVE, BYTE_CLASS_POSITIVE, BYTE_CLASS_DOT, BYTE_CLASS_DIGIT, BYTE_CLASS_EXPONENT}
+
+	// string content, literal letters and number bytes, the other bytes in the states are set below
+	set(stringStates, allClasses, TRANSITION_STRING_CONTENT)
+	set(escapeStates, allClasses, TRANSITION_DROP_ESCAPE)
+	set(unicodeEscapeStates, allClasses, TRANSITION_DROP_UNICODE_ESCAPE)
+	set([]int{STREAM_STATE_LITERAL}, allClasses, TRANSITION_LITERAL)
+	set([]int{STREAM_STATE_NUMBER}, allClasses, TRANSITION_END_NUMBER)
+	set([]int{STREAM_STATE_NUMBER}, numberBytes, TRANSITION_NUMBER)
+
+	// out of string, whitespace is padding, and the closer closes the innermost container whatever the state is
+	set(outOfStringStates, []int{BYTE_CLASS_WHITESPACE}, TRANSITION_PADDING)
+	set(outOfStringStates, closers, TRANSITION_CLOSE)
+	set(outOfStringStates, []int{BYTE_CLASS_QUOTE}, TRANSITION_INVALID_QUOTE)
+
+	// value
+	set(valueStates, []int{BYTE_CLASS_LEFT_BRACE}, TRANSITION_OPEN_OBJECT)
+	set(valueStates, []int{BYTE_CLASS_LEFT_BRACKET}, TRANSITION_OPEN_ARRAY)
+	set(valueStates, []int{BYTE_CLASS_QUOTE}, TRANSITION_START_STRING)
+	set(valueStates, []int{BYTE_CLASS_ESCAPE_LITERAL_HEX_LETTER, BYTE_CLASS_ESCAPE_LITERAL_LETTER}, TRANSITION_START_LITERAL)
+	set(valueStates, []int{BYTE_CLASS_NEGATIVE, BYTE_CLASS_DIGIT}, TRANSITION_START_NUMBER)
+
+	// object
+	set([]int{STREAM_STATE_OBJECT_KEY}, []int{BYTE_CLASS_QUOTE}, TRANSITION_START_KEY)
+	set([]int{STREAM_STATE_COLON}, []int{BYTE_CLASS_COLON}, TRANSITION_COLON)
+
+	// string, the escape sequence is in padding content until it is complete
+	set([]int{STREAM_STATE_KEY}, []int{BYTE_CLASS_QUOTE}, TRANSITION_END_KEY)
+	set([]int{STREAM_STATE_STRING}, []int{BYTE_CLASS_QUOTE}, TRANSITION_END_STRING)
+	set(stringStates, []int{BYTE_CLASS_ESCAPE_CHARACTER}, TRANSITION_START_ESCAPE)
+	set(escapeStates, []int{BYTE_CLASS_QUOTE, BYTE_CLASS_ESCAPE_CHARACTER, BYTE_CLASS_SLASH, BYTE_CLASS_ESCAPE_HEX_LETTER,
+		BYTE_CLASS_ESCAPE_LITERAL_HEX_LETTER, BYTE_CLASS_ESCAPE_LITERAL_LETTER, BYTE_CLASS_ESCAPE_LETTER}, TRANSITION_SHORT_ESCAPE)
+	set(escapeStates, []int{BYTE_CLASS_UNICODE_ESCAPE_LETTER}, TRANSITION_START_UNICODE_ESCAPE)
+	set(unicodeEscapeStates, hexDigits, TRANSITION_UNICODE_ESCAPE_HEX)
+
+	// after value
+	set(valueEndStates, []int{BYTE_CLASS_COMMA}, TRANSITION_COMMA)
+	set(valueEndStates, numberBytes, TRANSITION_DROP_NUMBER)
+	return table
+}
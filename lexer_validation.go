@@ -0,0 +1,270 @@
+package streamingjsongo
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// schema keywords reported in violation
+const (
+	SCHEMA_KEYWORD_FALSE                 = "false"
+	SCHEMA_KEYWORD_TYPE                  = "type"
+	SCHEMA_KEYWORD_ENUM                  = "enum"
+	SCHEMA_KEYWORD_ADDITIONAL_PROPERTIES = "additionalProperties"
+	SCHEMA_KEYWORD_MAX_LENGTH            = "maxLength"
+)
+
+// violation of schema found in JSON stream
+type SchemaViolation struct {
+	Path    string // JSON pointer (RFC 6901) of the violated value
+	Offset  int    // offset of the offending byte in JSON stream
+	Keyword string // the violated schema keyword
+	Message string
+}
+
+func (violation *SchemaViolation) Error() string {
+	return fmt.Sprintf("schema violation at `%s` (offset %d): %s", violation.Path, violation.Offset, violation.Message)
+}
+
+// state of incremental schema validation
+type schemaValidation struct {
+	enabled       bool
+	violation     *SchemaViolation // the first violation found, the lexer refuses more input after it
+	stringStart   int              // offset of string value measured by maxLength in JSONContent
+	stringScanned int              // offset of string value scanned in JSONContent
+	stringLength  int              // length in characters of string value scanned
+	enumStart     int              // offset of string value validated by enum in JSONContent, the decoded prefix is not serialized
+	enumScanned   int              // offset of string value decoded in JSONContent
+	enumPrefix    []byte           // decoded string value scanned
+}
+
+// new lexer with schema attached, the JSON stream is validated by schema while appending,
+// AppendString() returns *SchemaViolation as soon as the offending byte arrived.
+// type, enum (const), additionalProperties and maxLength are validated, integer is a number without fraction and exponent.
+func NewLexerWithSchemaValidation(schema *Schema) *Lexer {
+	lexer := NewLexerWithSchema(schema)
+	lexer.validation.enabled = true
+	lexer.validation.stringStart = -1
+	lexer.validation.enumStart = -1
+	return lexer
+}
+
+// get the first schema violation found in JSON stream, nil if no violation
+func (lexer *Lexer) SchemaViolation() *SchemaViolation {
+	return lexer.validation.violation
+}
+
+// report schema violation at the last appended byte, only the first violation is kept
+func (lexer *Lexer) reportSchemaViolation(keyword string, format string, args ...interface{}) {
+	if lexer.validation.violation != nil {
+		return
+	}
+	lexer.validation.violation = &SchemaViolation{
+		Path:    pathFramesToJSONPointer(lexer.pathFrames, lexer.JSONContent.String()),
+		Offset:  lexer.streamLength - 1,
+		Keyword: keyword,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// get schema type of value started by given token
+func getSchemaTypeOfValueStartToken(token int) string {
+	switch token {
+	case TOKEN_QUOTE:
+		return "string"
+	case TOKEN_ALPHABET_LOWERCASE_T, TOKEN_ALPHABET_LOWERCASE_F:
+		return "boolean"
+	case TOKEN_ALPHABET_LOWERCASE_N:
+		return "null"
+	case TOKEN_LEFT_BRACKET:
+		return "array"
+	case TOKEN_LEFT_BRACE:
+		return "object"
+	}
+	return "number"
+}
+
+// get schema type of JSON value
+func getSchemaTypeOfJSONValue(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+	switch value[0] {
+	case TOKEN_QUOTE_SYMBOL:
+		return "string"
+	case 't', 'f':
+		return "boolean"
+	case 'n':
+		return "null"
+	case TOKEN_LEFT_BRACKET_SYMBOL:
+		return "array"
+	case TOKEN_LEFT_BRACE_SYMBOL:
+		return "object"
+	}
+	return "number"
+}
+
+// validate the value started by given token with schema of current value
+func (lexer *Lexer) validateValueStart(token int) {
+	if !lexer.validation.enabled {
+		return
+	}
+	valueSchema := lexer.getCurrentValueSchema()
+	schema := valueSchema.resolve()
+	if schema == nil {
+		return
+	}
+	if schema.isFalse {
+		lexer.reportSchemaViolation(SCHEMA_KEYWORD_FALSE, "value is not allowed")
+		return
+	}
+	schemaType := getSchemaTypeOfValueStartToken(token)
+	if !valueSchema.allowsType(schemaType) && !(schemaType == "number" && valueSchema.allowsType("integer")) {
+		lexer.reportSchemaViolation(SCHEMA_KEYWORD_TYPE, "expected %s, got %s", strings.Join(schema.types, " or "), schemaType)
+		return
+	}
+	if !schema.allowsEnumType(schemaType) {
+		lexer.reportSchemaViolation(SCHEMA_KEYWORD_ENUM, "%s value is not in enum", schemaType)
+	}
+}
+
+// validate the step number moved into, fraction and exponent are not allowed if schema only allows integer, like `1.` and `1e`
+func (lexer *Lexer) validateNumberStep(step int) {
+	if !lexer.validation.enabled || (step != GRAMMAR_STEP_NUMBER_DOT && step != GRAMMAR_STEP_NUMBER_EXPONENT) {
+		return
+	}
+	valueSchema := lexer.getCurrentValueSchema()
+	if valueSchema.allowsType("number") || !valueSchema.allowsType("integer") {
+		return
+	}
+	lexer.reportSchemaViolation(SCHEMA_KEYWORD_TYPE, "expected integer, got number with fraction or exponent")
+}
+
+// check if any enum (or const) value is in given type, true if no enum
+func (schema *Schema) allowsEnumType(schemaType string) bool {
+	values := schema.enum
+	if len(schema.constValue) > 0 {
+		values = append(values[:0:0], schema.constValue)
+	}
+	if len(values) == 0 {
+		return true
+	}
+	for _, value := range values {
+		if getSchemaTypeOfJSONValue(string(value)) == schemaType {
+			return true
+		}
+	}
+	return false
+}
+
+// validate properity key of object frame, the finished key must be declared if additional properties are not allowed
+func (lexer *Lexer) validateObjectKey(frame *pathFrame, finished bool) {
+	if !lexer.validation.enabled {
+		return
+	}
+	schema := frame.schema.resolve()
+	if schema == nil || schema.additionalProperties == nil || !schema.additionalProperties.resolve().isFalse {
+		return
+	}
+	key := frame.lastKey(lexer.JSONContent.String())
+	if finished {
+		if _, propertySchema := schema.lookupProperty(key); propertySchema != nil {
+			return
+		}
+	} else if schema.hasPropertyCandidate(key) {
+		return
+	}
+	lexer.reportSchemaViolation(SCHEMA_KEYWORD_ADDITIONAL_PROPERTIES, "properity `%s` is not allowed", key)
+}
+
+// validate the last token, the key or string value in progress is validated for every byte
+func (lexer *Lexer) validateLastToken() error {
+	if lexer.validation.violation != nil {
+		return lexer.validation.violation
+	}
+	frame := lexer.getTopPathFrame()
+	if frame == nil || frame.schema == nil || !lexer.streamStoppedInAString() {
+		return nil
+	}
+	if !frame.isArray && frame.keyEnd < 0 {
+		lexer.validateObjectKey(frame, false)
+	} else {
+		lexer.validateStringValue(frame)
+	}
+	if lexer.validation.violation != nil {
+		return lexer.validation.violation
+	}
+	return nil
+}
+
+// validate string value in progress by enum (const) and maxLength
+func (lexer *Lexer) validateStringValue(frame *pathFrame) {
+	schema := lexer.getCurrentValueSchema().resolve()
+	if schema == nil {
+		return
+	}
+	content := lexer.JSONContent.String()
+	validation := &lexer.validation
+	if len(schema.enum) > 0 || len(schema.constValue) > 0 {
+		// decode the string content arrived since last validation only
+		if validation.enumStart != frame.valueStart {
+			validation.enumStart = frame.valueStart
+			validation.enumScanned = frame.valueStart + 1
+			validation.enumPrefix = validation.enumPrefix[:0]
+		}
+		var consumed int
+		validation.enumPrefix, consumed = appendDecodedJSONStringContent(validation.enumPrefix, content[validation.enumScanned:], false)
+		validation.enumScanned += consumed
+		if !schema.hasStringCandidate(validation.enumPrefix) {
+			lexer.reportSchemaViolation(SCHEMA_KEYWORD_ENUM, "string `%s` is not in enum", validation.enumPrefix)
+			return
+		}
+	}
+	if schema.maxLength == nil {
+		return
+	}
+	if validation.stringStart != frame.valueStart {
+		validation.stringStart = frame.valueStart
+		validation.stringScanned = frame.valueStart + 1
+		validation.stringLength = 0
+	}
+	validation.stringScanned, validation.stringLength = countJSONStringLength(content, validation.stringScanned, validation.stringLength)
+	if validation.stringLength > *schema.maxLength {
+		lexer.reportSchemaViolation(SCHEMA_KEYWORD_MAX_LENGTH, "string is longer than %d characters", *schema.maxLength)
+	}
+}
+
+// count characters of JSON string content from given offset, returns the scanned offset and the length.
+// the escape sequences count as one character, and the surrogate pair like `\ud83d\ude00` counts as one character.
+func countJSONStringLength(s string, offset int, length int) (int, int) {
+	for offset < len(s) {
+		c := s[offset]
+		if c != TOKEN_ESCAPE_CHARACTER_SYMBOL {
+			if utf8.RuneStart(c) {
+				length++
+			}
+			offset++
+			continue
+		}
+		// escape sequence not finished
+		if offset+1 >= len(s) {
+			break
+		}
+		if s[offset+1] != 'u' {
+			length++
+			offset += 2
+			continue
+		}
+		if offset+6 > len(s) {
+			break
+		}
+		// low surrogate is counted with high surrogate
+		if highByte := strings.ToLower(s[offset+2 : offset+4]); highByte < "dc" || highByte > "df" {
+			length++
+		}
+		offset += 6
+	}
+	return offset, length
+}
@@ -0,0 +1,90 @@
+package streamingjsongo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_VALIDATION_SCHEMA = `{
+	"type": "object",
+	"properties": {
+		"name": {"type": "string", "maxLength": 5},
+		"count": {"type": "integer"},
+		"status": {"enum": ["pending", "in_progress", "done"]},
+		"tags": {"type": "array", "items": {"type": "string"}},
+		"owner": {"$ref": "#/$defs/user"}
+	},
+	"additionalProperties": false,
+	"$defs": {
+		"user": {"type": "object", "properties": {"id": {"type": "integer"}}}
+	}
+}`
+
+func TestNewLexerWithSchemaValidation_violation(t *testing.T) {
+	streamingJSONCase := map[string]SchemaViolation{
+		`{"count": "1"}`:            {Path: "/count", Offset: 10, Keyword: SCHEMA_KEYWORD_TYPE, Message: "expected integer, got string"},
+		`{"count": -1, "tags": {}}`: {Path: "/tags", Offset: 22, Keyword: SCHEMA_KEYWORD_TYPE, Message: "expected array, got object"},
+		`{"tags": ["a", 1]}`:        {Path: "/tags/1", Offset: 15, Keyword: SCHEMA_KEYWORD_TYPE, Message: "expected string, got number"},
+		`{"owner": {"id": true}}`:   {Path: "/owner/id", Offset: 17, Keyword: SCHEMA_KEYWORD_TYPE, Message: "expected integer, got boolean"},
+		`[]`:                        {Path: "", Offset: 0, Keyword: SCHEMA_KEYWORD_TYPE, Message: "expected object, got array"},
+		`{"status": "in_pr0gress"}`: {Path: "/status", Offset: 17, Keyword: SCHEMA_KEYWORD_ENUM, Message: "string `in_pr0` is not in enum"},
+		`{"status": null}`:          {Path: "/status", Offset: 11, Keyword: SCHEMA_KEYWORD_ENUM, Message: "null value is not in enum"},
+		`{"name": "a", "nick": ""}`: {Path: "/ni", Offset: 16, Keyword: SCHEMA_KEYWORD_ADDITIONAL_PROPERTIES, Message: "properity `ni` is not allowed"},
+		`{"nam": 1}`:                {Path: "/nam", Offset: 5, Keyword: SCHEMA_KEYWORD_ADDITIONAL_PROPERTIES, Message: "properity `nam` is not allowed"},
+		`{"names": []}`:             {Path: "/names", Offset: 6, Keyword: SCHEMA_KEYWORD_ADDITIONAL_PROPERTIES, Message: "properity `names` is not allowed"},
+		`{"name": "中\n文字 6"}`:       {Path: "/name", Offset: 22, Keyword: SCHEMA_KEYWORD_MAX_LENGTH, Message: "string is longer than 5 characters"},
+		`{"count": 1.5}`:            {Path: "/count", Offset: 11, Keyword: SCHEMA_KEYWORD_TYPE, Message: "expected integer, got number with fraction or exponent"},
+		`{"owner": {"id": 2E1}}`:    {Path: "/owner/id", Offset: 18, Keyword: SCHEMA_KEYWORD_TYPE, Message: "expected integer, got number with fraction or exponent"},
+		`{"status": "in\u005fpr0"}`: {Path: "/status", Offset: 22, Keyword: SCHEMA_KEYWORD_ENUM, Message: "string `in_pr0` is not in enum"},
+	}
+	schema, err := NewSchema([]byte(TEST_VALIDATION_SCHEMA))
+	assert.Nil(t, err)
+	for testCase, expect := range streamingJSONCase {
+		// append byte by byte, the violation is reported as soon as the offending byte arrived
+		lexer := NewLexerWithSchemaValidation(schema)
+		var errInAppend error
+		for i := 0; i < len(testCase) && errInAppend == nil; i++ {
+			errInAppend = lexer.AppendString(testCase[i : i+1])
+			if errInAppend != nil {
+				assert.Equal(t, expect.Offset, i, "unexpected offending byte for `%s`", testCase)
+			}
+		}
+		assert.Equal(t, &expect, errInAppend, "unexpected violation for `%s`", testCase)
+		assert.Equal(t, &expect, lexer.SchemaViolation())
+		// the violation is sticky
+		assert.Equal(t, errInAppend, lexer.AppendString(`}`))
+
+		// append at once
+		lexer = NewLexerWithSchemaValidation(schema)
+		assert.Equal(t, &expect, lexer.AppendString(testCase), "unexpected violation for `%s`", testCase)
+	}
+}
+
+func TestNewLexerWithSchemaValidation_valid(t *testing.T) {
+	streamingJSONContent := `{"name": "😀 \"b", "count": -12, "status": "in\u005fprogress", "tags": ["x", "y"], "owner": {"id": 3}}`
+	schema, err := NewSchema([]byte(TEST_VALIDATION_SCHEMA))
+	assert.Nil(t, err)
+	lexer := NewLexerWithSchemaValidation(schema)
+	for _, char := range streamingJSONContent {
+		assert.Nil(t, lexer.AppendString(string(char)))
+	}
+	assert.Nil(t, lexer.SchemaViolation())
+	assert.Equal(t, streamingJSONContent, lexer.CompleteJSON())
+}
+
+func TestCountJSONStringLength(t *testing.T) {
+	stringCase := map[string]int{
+		``:       0,
+		`abc`:    3,
+		`中文`:     2,
+		`\"\\\n`: 3,
+		`中😀😀`:    3,
+		`a\`:     1,
+		`a\u4e`:  1,
+	}
+	for testCase, expect := range stringCase {
+		_, length := countJSONStringLength(testCase, 0, 0)
+		assert.Equal(t, expect, length, "unexpected length for `%s`", testCase)
+	}
+}
@@ -0,0 +1,88 @@
+package streamingjsongo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// recorded chunk of JSON stream, the fixture format is JSON Lines of `{"t": 12.5, "chunk": "{\"a"}`
+type ReplayChunk struct {
+	T     float64 `json:"t"`     // milliseconds since JSON stream started
+	Chunk string  `json:"chunk"` // raw chunk of JSON stream
+}
+
+// options for replay
+type ReplayOptions struct {
+	Speed float64 // timing acceleration, 1 for original timing, 2 for twice as fast, 0 for no waiting
+}
+
+// result of a replayed chunk
+type ReplayResult struct {
+	Index     int           // index of chunk
+	T         float64       // milliseconds since JSON stream started in record
+	Chunk     string        // raw chunk of JSON stream
+	Completed string        // completed JSON after the chunk appended
+	Valid     bool          // completed JSON is valid under encoding/json
+	Err       error         // error returned by AppendString()
+	Latency   time.Duration // time spent on AppendString() and CompleteJSON()
+}
+
+// read recorded chunks from JSON Lines fixture, blank lines are ignored
+func ReadReplayChunks(r io.Reader) ([]ReplayChunk, error) {
+	var chunks []ReplayChunk
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 64*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var chunk ReplayChunk
+		if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
+			return nil, fmt.Errorf("invalid replay chunk at line %d: %s", line, err)
+		}
+		chunks = append(chunks, chunk)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return chunks, nil
+}
+
+// feed recorded chunks into a new lexer with original or accelerated timing, and report every chunk to callback.
+// replay stops when callback returns an error, options can be nil for original timing.
+func Replay(chunks []ReplayChunk, options *ReplayOptions, callback func(result ReplayResult) error) error {
+	speed := 1.0
+	if options != nil {
+		speed = options.Speed
+	}
+	lexer := NewLexer()
+	startedAt := time.Now()
+	for i, chunk := range chunks {
+		if speed > 0 {
+			scheduledAt := startedAt.Add(time.Duration(chunk.T / speed * float64(time.Millisecond)))
+			if wait := time.Until(scheduledAt); wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+		result := ReplayResult{
+			Index: i,
+			T:     chunk.T,
+			Chunk: chunk.Chunk,
+		}
+		appendedAt := time.Now()
+		result.Err = lexer.AppendString(chunk.Chunk)
+		result.Completed = lexer.CompleteJSON()
+		result.Latency = time.Since(appendedAt)
+		result.Valid = json.Valid([]byte(result.Completed))
+		if err := callback(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
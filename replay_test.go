@@ -0,0 +1,63 @@
+package streamingjsongo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadReplayChunks(t *testing.T) {
+	fixture := `{"t": 0, "chunk": "{\"a"}
+
+{"t": 12.5, "chunk": "\":[tr"}
+`
+	chunks, err := ReadReplayChunks(strings.NewReader(fixture))
+	assert.Nil(t, err)
+	assert.Equal(t, []ReplayChunk{{T: 0, Chunk: `{"a`}, {T: 12.5, Chunk: `":[tr`}}, chunks)
+
+	_, err = ReadReplayChunks(strings.NewReader("{\"t\": 0}\n{"))
+	assert.EqualError(t, err, "invalid replay chunk at line 2: unexpected end of JSON input")
+}
+
+func TestReplay(t *testing.T) {
+	chunks := []ReplayChunk{{T: 0, Chunk: `{"a`}, {T: 10, Chunk: `":[tr`}, {T: 20, Chunk: `ue]}`}}
+	var results []ReplayResult
+	err := Replay(chunks, &ReplayOptions{Speed: 0}, func(result ReplayResult) error {
+		results = append(results, result)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(results))
+	for i, expect := range []string{`{"a":null}`, `{"a":[true]}`, `{"a":[true]}`} {
+		assert.Equal(t, i, results[i].Index)
+		assert.Equal(t, chunks[i].Chunk, results[i].Chunk)
+		assert.Equal(t, expect, results[i].Completed)
+		assert.True(t, results[i].Valid)
+		assert.Nil(t, results[i].Err)
+	}
+}
+
+func TestReplay_timing(t *testing.T) {
+	chunks := []ReplayChunk{{T: 0, Chunk: `[`}, {T: 40, Chunk: `1]`}}
+	startedAt := time.Now()
+	err := Replay(chunks, &ReplayOptions{Speed: 2}, func(result ReplayResult) error {
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, int64(time.Since(startedAt)), int64(20*time.Millisecond))
+}
+
+func TestReplay_stop(t *testing.T) {
+	chunks := []ReplayChunk{{T: 0, Chunk: `[`}, {T: 0, Chunk: `1]`}}
+	errStop := errors.New("stop")
+	calls := 0
+	err := Replay(chunks, &ReplayOptions{}, func(result ReplayResult) error {
+		calls++
+		return errStop
+	})
+	assert.Equal(t, errStop, err)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,416 @@
+package streamingjsongo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// max depth of nested placeholders generated by schema, for recursive schema
+const SCHEMA_PLACEHOLDER_MAX_DEPTH = 8
+
+// JSON Schema (draft 2020-12 subset) for guiding completion.
+// supported keywords: type, properties, required, additionalProperties, items, prefixItems, enum, const, default, maxLength, $ref, $defs (definitions).
+type Schema struct {
+	isFalse              bool // the boolean schema `false`, nothing is valid
+	types                []string
+	properties           map[string]*Schema
+	propertyNames        []string // properity names in declared order
+	required             []string
+	additionalProperties *Schema
+	items                *Schema
+	prefixItems          []*Schema
+	enum                 []json.RawMessage
+	constValue           json.RawMessage
+	defaultValue         json.RawMessage
+	maxLength            *int
+	nullable             bool // null is allowed besides the referred schema, for Go pointer types
+	caseInsensitiveKeys  bool // properity keys match names case-insensitively, like encoding/json decodes into struct fields
+	ref                  string
+	refSchema            *Schema
+	defs                 map[string]*Schema
+}
+
+// schema keywords in JSON
+type schemaInJSON struct {
+	Type                 json.RawMessage    `json:"type"`
+	Properties           json.RawMessage    `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *Schema            `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+	PrefixItems          []*Schema          `json:"prefixItems"`
+	Enum                 []json.RawMessage  `json:"enum"`
+	Const                json.RawMessage    `json:"const"`
+	Default              json.RawMessage    `json:"default"`
+	MaxLength            *int               `json:"maxLength"`
+	Ref                  string             `json:"$ref"`
+	Defs                 map[string]*Schema `json:"$defs"`
+	Definitions          map[string]*Schema `json:"definitions"`
+}
+
+// new schema from JSON Schema document
+func NewSchema(schemaInJSON []byte) (*Schema, error) {
+	schema := &Schema{}
+	if err := json.Unmarshal(schemaInJSON, schema); err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %s", err)
+	}
+	if err := schema.resolveRefs(schema, map[*Schema]bool{}); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// new lexer with schema attached, the completed JSON will be guided by schema
+func NewLexerWithSchema(schema *Schema) *Lexer {
+	lexer := NewLexer()
+	lexer.schema = schema
+	return lexer
+}
+
+// decode schema from JSON, the boolean schema is supported
+func (schema *Schema) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch string(data) {
+	case "true":
+		return nil
+	case "false":
+		schema.isFalse = true
+		return nil
+	}
+	var keywords schemaInJSON
+	if err := json.Unmarshal(data, &keywords); err != nil {
+		return err
+	}
+	if len(keywords.Type) > 0 {
+		if keywords.Type[0] == TOKEN_LEFT_BRACKET_SYMBOL {
+			if err := json.Unmarshal(keywords.Type, &schema.types); err != nil {
+				return err
+			}
+		} else {
+			var schemaType string
+			if err := json.Unmarshal(keywords.Type, &schemaType); err != nil {
+				return err
+			}
+			schema.types = []string{schemaType}
+		}
+	}
+	if len(keywords.Properties) > 0 {
+		if err := schema.unmarshalProperties(keywords.Properties); err != nil {
+			return err
+		}
+	}
+	schema.required = keywords.Required
+	schema.additionalProperties = keywords.AdditionalProperties
+	schema.items = keywords.Items
+	schema.prefixItems = keywords.PrefixItems
+	schema.enum = keywords.Enum
+	schema.constValue = keywords.Const
+	schema.defaultValue = keywords.Default
+	schema.maxLength = keywords.MaxLength
+	schema.ref = keywords.Ref
+	schema.defs = keywords.Defs
+	if schema.defs == nil {
+		schema.defs = keywords.Definitions
+	}
+	return nil
+}
+
+// decode properties and keep the declared order of properity names
+func (schema *Schema) unmarshalProperties(data []byte) error {
+	if err := json.Unmarshal(data, &schema.properties); err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// skip `{`
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+	for decoder.More() {
+		name, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		schema.propertyNames = append(schema.propertyNames, name.(string))
+		var skip json.RawMessage
+		if err := decoder.Decode(&skip); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolve `$ref` in schema and sub-schemas, only local reference like `#/$defs/name` is supported
+func (schema *Schema) resolveRefs(root *Schema, visited map[*Schema]bool) error {
+	if schema == nil || visited[schema] {
+		return nil
+	}
+	visited[schema] = true
+	if schema.ref != "" {
+		refSchema, err := root.lookupRef(schema.ref)
+		if err != nil {
+			return err
+		}
+		schema.refSchema = refSchema
+	}
+	subSchemas := []*Schema{schema.additionalProperties, schema.items}
+	subSchemas = append(subSchemas, schema.prefixItems...)
+	for _, name := range schema.propertyNames {
+		subSchemas = append(subSchemas, schema.properties[name])
+	}
+	for _, def := range schema.defs {
+		subSchemas = append(subSchemas, def)
+	}
+	for _, subSchema := range subSchemas {
+		if err := subSchema.resolveRefs(root, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookup local reference in root schema
+func (schema *Schema) lookupRef(ref string) (*Schema, error) {
+	if ref == "#" {
+		return schema, nil
+	}
+	for _, prefix := range []string{"#/$defs/", "#/definitions/"} {
+		if strings.HasPrefix(ref, prefix) {
+			if def, ok := schema.defs[strings.TrimPrefix(ref, prefix)]; ok {
+				return def, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unsupported JSON schema reference: `%s`", ref)
+}
+
+// follow `$ref` of schema
+func (schema *Schema) resolve() *Schema {
+	for i := 0; schema != nil && schema.refSchema != nil && i < SCHEMA_PLACEHOLDER_MAX_DEPTH; i++ {
+		schema = schema.refSchema
+	}
+	return schema
+}
+
+// get schema of properity by given key, nil if any value is allowed
+func (schema *Schema) getPropertySchema(key string) *Schema {
+	schema = schema.resolve()
+	if schema == nil {
+		return nil
+	}
+	if _, propertySchema := schema.lookupProperty(key); propertySchema != nil {
+		return propertySchema
+	}
+	return schema.additionalProperties
+}
+
+// get declared name and schema of properity by given key, the exact name is preferred to the case-insensitive one.
+// empty name and nil schema if the key is not declared
+func (schema *Schema) lookupProperty(key string) (string, *Schema) {
+	if propertySchema, ok := schema.properties[key]; ok {
+		return key, propertySchema
+	}
+	if !schema.caseInsensitiveKeys {
+		return "", nil
+	}
+	for _, name := range schema.propertyNames {
+		if strings.EqualFold(name, key) {
+			return name, schema.properties[name]
+		}
+	}
+	return "", nil
+}
+
+// check if any properity name starts with given prefix of key
+func (schema *Schema) hasPropertyCandidate(prefix string) bool {
+	for _, name := range schema.propertyNames {
+		if len(name) < len(prefix) {
+			continue
+		}
+		if name[:len(prefix)] == prefix || (schema.caseInsensitiveKeys && strings.EqualFold(name[:len(prefix)], prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// get schema of array element by given index, nil if any value is allowed
+func (schema *Schema) getItemSchema(index int) *Schema {
+	schema = schema.resolve()
+	if schema == nil {
+		return nil
+	}
+	if index >= 0 && index < len(schema.prefixItems) {
+		return schema.prefixItems[index]
+	}
+	return schema.items
+}
+
+// check if schema allows given type
+func (schema *Schema) allowsType(schemaType string) bool {
+	if schemaType == "null" && schema != nil && schema.nullable {
+		return true
+	}
+	schema = schema.resolve()
+	if schema == nil || len(schema.types) == 0 {
+		return true
+	}
+	for _, allowedType := range schema.types {
+		if allowedType == schemaType || (allowedType == "number" && schemaType == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// get properity names which is not in given keys, with the given prefix, in declared order
+func (schema *Schema) getMissingPropertyNames(keys []string, prefix string, requiredOnly bool) []string {
+	schema = schema.resolve()
+	if schema == nil {
+		return nil
+	}
+	names := schema.propertyNames
+	if requiredOnly {
+		names = schema.required
+	}
+	var missingNames []string
+	for _, name := range names {
+		if !strings.HasPrefix(name, prefix) || containsString(keys, name) {
+			continue
+		}
+		missingNames = append(missingNames, name)
+	}
+	return missingNames
+}
+
+// get string enum (and const) candidates with the given prefix
+func (schema *Schema) getStringCandidates(prefix string) []string {
+	schema = schema.resolve()
+	if schema == nil {
+		return nil
+	}
+	values := schema.enum
+	if len(schema.constValue) > 0 {
+		values = []json.RawMessage{schema.constValue}
+	}
+	var candidates []string
+	for _, value := range values {
+		var candidate string
+		if json.Unmarshal(value, &candidate) != nil || !strings.HasPrefix(candidate, prefix) {
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+// check if any string in enum (or const) starts with given prefix
+func (schema *Schema) hasStringCandidate(prefix []byte) bool {
+	schema = schema.resolve()
+	if schema == nil {
+		return false
+	}
+	values := schema.enum
+	if len(schema.constValue) > 0 {
+		values = []json.RawMessage{schema.constValue}
+	}
+	for _, value := range values {
+		var candidate string
+		if json.Unmarshal(value, &candidate) == nil && len(candidate) >= len(prefix) && candidate[:len(prefix)] == string(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// generate type-correct placeholder value in JSON for schema
+func (schema *Schema) placeholder(depth int) string {
+	schema = schema.resolve()
+	if schema == nil || schema.isFalse || depth > SCHEMA_PLACEHOLDER_MAX_DEPTH {
+		return "null"
+	}
+	if len(schema.constValue) > 0 {
+		return compactJSON(schema.constValue)
+	}
+	if len(schema.defaultValue) > 0 {
+		return compactJSON(schema.defaultValue)
+	}
+	if len(schema.enum) > 0 {
+		return compactJSON(schema.enum[0])
+	}
+	schemaType := ""
+	if len(schema.types) > 0 {
+		schemaType = schema.types[0]
+	} else if len(schema.properties) > 0 || len(schema.required) > 0 {
+		schemaType = "object"
+	} else if schema.items != nil || len(schema.prefixItems) > 0 {
+		schemaType = "array"
+	}
+	switch schemaType {
+	case "string":
+		return `""`
+	case "number", "integer":
+		return "0"
+	case "boolean":
+		return "false"
+	case "array":
+		var placeholder strings.Builder
+		placeholder.WriteByte(TOKEN_LEFT_BRACKET_SYMBOL)
+		for i, itemSchema := range schema.prefixItems {
+			if i > 0 {
+				placeholder.WriteByte(TOKEN_COMMA_SYMBOL)
+			}
+			placeholder.WriteString(itemSchema.placeholder(depth + 1))
+		}
+		placeholder.WriteByte(TOKEN_RIGHT_BRACKET_SYMBOL)
+		return placeholder.String()
+	case "object":
+		var placeholder strings.Builder
+		placeholder.WriteByte(TOKEN_LEFT_BRACE_SYMBOL)
+		schema.writeRequiredProperties(&placeholder, nil, false, depth+1)
+		placeholder.WriteByte(TOKEN_RIGHT_BRACE_SYMBOL)
+		return placeholder.String()
+	}
+	return "null"
+}
+
+// write required properties not in given keys with placeholder values, like `"a":0,"b":""`
+func (schema *Schema) writeRequiredProperties(builder *strings.Builder, keys []string, leadingComma bool, depth int) {
+	for _, name := range schema.getMissingPropertyNames(keys, "", true) {
+		if leadingComma {
+			builder.WriteByte(TOKEN_COMMA_SYMBOL)
+		}
+		leadingComma = true
+		builder.WriteString(encodeJSONString(name))
+		builder.WriteByte(TOKEN_COLON_SYMBOL)
+		builder.WriteString(schema.getPropertySchema(name).placeholder(depth))
+	}
+}
+
+// encode string into JSON string with quotes, HTML characters are not escaped
+func encodeJSONString(s string) string {
+	var encoded bytes.Buffer
+	encoder := json.NewEncoder(&encoded)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(s)
+	return strings.TrimRight(encoded.String(), "\n")
+}
+
+// compact JSON value, return it as it is if invalid
+func compactJSON(value json.RawMessage) string {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, value); err != nil {
+		return string(value)
+	}
+	return compacted.String()
+}
+
+// check if string in slice
+func containsString(slice []string, s string) bool {
+	for _, element := range slice {
+		if element == s {
+			return true
+		}
+	}
+	return false
+}
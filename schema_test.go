@@ -0,0 +1,140 @@
+package streamingjsongo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_SCHEMA = `{
+	"type": "object",
+	"properties": {
+		"name": {"type": "string"},
+		"count": {"type": "integer"},
+		"status": {"enum": ["pending", "in_progress", "done"]},
+		"tags": {"type": "array", "items": {"type": "string"}},
+		"owner": {"$ref": "#/$defs/user"}
+	},
+	"required": ["name", "count", "status"],
+	"$defs": {
+		"user": {
+			"type": "object",
+			"properties": {"id": {"type": "integer"}, "admin": {"type": "boolean", "default": true}},
+			"required": ["id"]
+		}
+	}
+}`
+
+func TestNewSchema_invalid(t *testing.T) {
+	_, err := NewSchema([]byte(`{"type":`))
+	assert.NotNil(t, err)
+	_, err = NewSchema([]byte(`{"$ref": "https://example.com/schema.json"}`))
+	assert.Equal(t, "unsupported JSON schema reference: `https://example.com/schema.json`", err.Error())
+}
+
+func TestSchemaPlaceholder(t *testing.T) {
+	schemaCase := map[string]string{
+		`true`:                                  `null`,
+		`{"type": "string"}`:                    `""`,
+		`{"type": ["integer", "null"]}`:         `0`,
+		`{"type": "boolean"}`:                   `false`,
+		`{"type": "array"}`:                     `[]`,
+		`{"prefixItems": [{"type": "number"}]}`: `[0]`,
+		`{"enum": ["a", "b"]}`:                  `"a"`,
+		`{"const": {"a": 1}}`:                   `{"a":1}`,
+		`{"type": "string", "default": "none"}`: `"none"`,
+		`{"required": ["a"]}`:                   `{"a":null}`,
+		`{"properties": {"next": {"$ref": "#"}}, "required": ["next"]}`: `{"next":{"next":{"next":{"next":{"next":{"next":{"next":{"next":{"next":null}}}}}}}}}`,
+	}
+	for schemaInJSON, expect := range schemaCase {
+		schema, err := NewSchema([]byte(schemaInJSON))
+		assert.Nil(t, err)
+		assert.Equal(t, expect, schema.placeholder(0), "unexpected placeholder for schema `%s`", schemaInJSON)
+	}
+}
+
+func TestCompleteJSON_withSchema(t *testing.T) {
+	streamingJSONCase := map[string]string{
+		`{"count":`:                               `{"count":0,"name":"","status":"pending"}`,
+		`{"name":"x","count":1,"status":"in_pro`:  `{"name":"x","count":1,"status":"in_progress"}`,
+		`{"name":"x","count":1,"status":"`:        `{"name":"x","count":1,"status":"pending"}`,
+		`{"name":"x","count":1,"status":"unknown`: `{"name":"x","count":1,"status":"unknown"}`,
+		`{"na`:           `{"name":"","count":0,"status":"pending"}`,
+		`{"name":"x","t`: `{"name":"x","tags":[],"count":0,"status":"pending"}`,
+		`{"name":"x","count":1,"status":"done","x`:      `{"name":"x","count":1,"status":"done","x":null}`,
+		`{"name":"x","count":1,"status":"done","owner"`: `{"name":"x","count":1,"status":"done","owner":{"id":0}}`,
+		`{"owner":{"ad`:                          `{"owner":{"admin":true,"id":0},"name":"","count":0,"status":"pending"}`,
+		`{"tags":["a",`:                          `{"tags":["a"],"name":"","count":0,"status":"pending"}`,
+		`{"name":"x","count":1,"status":"done"}`: `{"name":"x","count":1,"status":"done"}`,
+	}
+	schema, err := NewSchema([]byte(TEST_SCHEMA))
+	assert.Nil(t, err)
+	for testCase, expect := range streamingJSONCase {
+		lexer := NewLexerWithSchema(schema)
+		assert.Nil(t, lexer.AppendString(testCase))
+		assert.Equal(t, expect, lexer.CompleteJSON(), "unexpected completion for `%s`", testCase)
+		completion := lexer.Completion()
+		assert.Equal(t, lexer.CompleteJSON(), completion.Stable+completion.Volatile)
+	}
+}
+
+func TestCompleteJSON_withSchemaUndeclaredKeys(t *testing.T) {
+	// only the declared keys are kept in frame, the undeclared and repeated keys are not
+	schema, err := NewSchema([]byte(TEST_SCHEMA))
+	assert.Nil(t, err)
+	lexer := NewLexerWithSchema(schema)
+	assert.Nil(t, lexer.AppendString(`{"name":"x","x1":1,"x2":2,"name":"y","x3":3,"cou`))
+	assert.Equal(t, []string{"name"}, lexer.pathFrames[0].keys)
+	assert.Equal(t, `{"name":"x","x1":1,"x2":2,"name":"y","x3":3,"count":0,"status":"pending"}`, lexer.CompleteJSON())
+}
+
+func TestCompleteJSON_withSchemaEveryPrefixValid(t *testing.T) {
+	type user struct {
+		ID    int  `json:"id"`
+		Admin bool `json:"admin"`
+	}
+	type document struct {
+		Name   string   `json:"name"`
+		Count  int      `json:"count"`
+		Status string   `json:"status"`
+		Tags   []string `json:"tags"`
+		Owner  *user    `json:"owner"`
+	}
+	streamingJSONContent := `{"name": "a\"b", "count": -12, "status": "in_progress", "tags": ["x", "y"], "owner": {"id": 3, "admin": true}}`
+	schema, err := NewSchema([]byte(TEST_SCHEMA))
+	assert.Nil(t, err)
+	lexer := NewLexerWithSchema(schema)
+	for _, char := range streamingJSONContent {
+		assert.Nil(t, lexer.AppendString(string(char)))
+		completed := lexer.CompleteJSON()
+		// type-correct
+		var decoded document
+		assert.Nil(t, json.Unmarshal([]byte(completed), &decoded), "invalid completion `%s`", completed)
+		// required properties present and not null
+		var properties map[string]json.RawMessage
+		assert.Nil(t, json.Unmarshal([]byte(completed), &properties))
+		for _, name := range []string{"name", "count", "status"} {
+			value, ok := properties[name]
+			assert.True(t, ok && string(value) != "null", "missing required properity `%s` in `%s`", name, completed)
+		}
+		assert.Contains(t, []string{"pending", "in_progress", "done"}, decoded.Status, "unexpected enum value in `%s`", completed)
+	}
+}
+
+func TestCompletionPatch_withSchema(t *testing.T) {
+	streamingJSONContent := `{"name": "a", "count": 12, "status": "in_progress", "owner": {"id": 3}}`
+	schema, err := NewSchema([]byte(TEST_SCHEMA))
+	assert.Nil(t, err)
+	lexer := NewLexerWithSchema(schema)
+	var document interface{}
+	for _, char := range streamingJSONContent {
+		assert.Nil(t, lexer.AppendString(string(char)))
+		operations, err := lexer.CompletionPatch()
+		assert.Nil(t, err)
+		document = applyPatchOperations(t, document, operations)
+		var expect interface{}
+		assert.Nil(t, json.Unmarshal([]byte(lexer.CompleteJSON()), &expect))
+		assert.Equal(t, expect, document)
+	}
+}
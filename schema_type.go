@@ -0,0 +1,161 @@
+package streamingjsongo
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// new lexer with schema derived from Go type T, the completed JSON will be guided by the type.
+// like `{"tags":` completes to `{"tags":[]}` for field `Tags []string` with tag `json:"tags"`.
+func NewTypedLexer[T any]() *Lexer {
+	return NewLexerWithSchema(NewSchemaFromType(reflect.TypeOf((*T)(nil)).Elem()))
+}
+
+// new lexer with schema derived from Go type T, and the JSON stream is validated by the type,
+// the unknown key of struct and the value in wrong type are reported by AppendString().
+func NewTypedLexerWithValidation[T any]() *Lexer {
+	return NewLexerWithSchemaValidation(NewSchemaFromType(reflect.TypeOf((*T)(nil)).Elem()))
+}
+
+// new schema derived from Go type by the rules of encoding/json.
+// struct fields are named by `json` tag and matched case-insensitively, unknown keys of struct are not allowed, the pointer is nullable,
+// and types implementing json.Unmarshaler accept any value (encoding.TextUnmarshaler accept string).
+func NewSchemaFromType(t reflect.Type) *Schema {
+	return newSchemaFromType(t, map[reflect.Type]*Schema{})
+}
+
+// new schema derived from Go type, the struct schemas in building are cached for recursive types
+func newSchemaFromType(t reflect.Type, structSchemas map[reflect.Type]*Schema) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		nullable = true
+		t = t.Elem()
+	}
+	schema := newSchemaFromNonPointerType(t, structSchemas)
+	if nullable {
+		// refer to the schema instead of copying it, the struct schema may be still in building for recursive types
+		return &Schema{nullable: true, refSchema: schema}
+	}
+	return schema
+}
+
+// new schema derived from Go type which is not pointer
+func newSchemaFromNonPointerType(t reflect.Type, structSchemas map[reflect.Type]*Schema) *Schema {
+	pointerType := reflect.PtrTo(t)
+	if t.Implements(jsonUnmarshalerType) || pointerType.Implements(jsonUnmarshalerType) {
+		if t.Implements(textUnmarshalerType) || pointerType.Implements(textUnmarshalerType) {
+			return &Schema{types: []string{"string"}}
+		}
+		return &Schema{}
+	}
+	if t.Implements(textUnmarshalerType) || pointerType.Implements(textUnmarshalerType) {
+		return &Schema{types: []string{"string"}}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{types: []string{"boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{types: []string{"integer"}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{types: []string{"number"}}
+	case reflect.String:
+		return &Schema{types: []string{"string"}}
+	case reflect.Slice:
+		// []byte is encoded as base64 string
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{types: []string{"string"}}
+		}
+		return &Schema{types: []string{"array", "null"}, items: newSchemaFromType(t.Elem(), structSchemas)}
+	case reflect.Array:
+		return &Schema{types: []string{"array"}, items: newSchemaFromType(t.Elem(), structSchemas)}
+	case reflect.Map:
+		return &Schema{types: []string{"object", "null"}, additionalProperties: newSchemaFromType(t.Elem(), structSchemas)}
+	case reflect.Struct:
+		if schema, ok := structSchemas[t]; ok {
+			return schema
+		}
+		schema := &Schema{types: []string{"object"}, properties: map[string]*Schema{}, additionalProperties: &Schema{isFalse: true}, caseInsensitiveKeys: true}
+		structSchemas[t] = schema
+		addStructFieldsToSchema(schema, t, structSchemas)
+		return schema
+	}
+	// interface and anything else accepts any value
+	return &Schema{}
+}
+
+// add exported fields of struct into properties of schema, the fields of embedded struct are promoted
+func addStructFieldsToSchema(schema *Schema, t reflect.Type, structSchemas map[reflect.Type]*Schema) {
+	var embeddedTypes []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		fieldType := field.Type
+		if field.Anonymous && name == "" {
+			for fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				embeddedTypes = append(embeddedTypes, fieldType)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if _, ok := schema.properties[name]; ok {
+			continue
+		}
+		fieldSchema := newSchemaFromType(field.Type, structSchemas)
+		if containsString(strings.Split(options, ","), "string") && isStringOptionApplicable(field.Type) {
+			fieldSchema = newSchemaForStringOption(field.Type)
+		}
+		schema.properties[name] = fieldSchema
+		schema.propertyNames = append(schema.propertyNames, name)
+	}
+	// the fields in outer struct take precedence
+	for _, embeddedType := range embeddedTypes {
+		addStructFieldsToSchema(schema, embeddedType, structSchemas)
+	}
+}
+
+// new schema for field with `,string` option, the placeholder is the zero value quoted, like `"0"`
+func newSchemaForStringOption(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	zeroValue, _ := json.Marshal(reflect.Zero(t).Interface())
+	return &Schema{types: []string{"string"}, defaultValue: json.RawMessage(encodeJSONString(string(zeroValue)))}
+}
+
+// check if `,string` option of json tag is applicable for type, the option encodes number, boolean and string as string
+func isStringOptionApplicable(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
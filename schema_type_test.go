@@ -0,0 +1,113 @@
+package streamingjsongo
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTypedBase struct {
+	ID string `json:"id"`
+}
+
+type testTypedNode struct {
+	Value    int              `json:"value"`
+	Children []*testTypedNode `json:"children"`
+}
+
+type testTypedDocument struct {
+	testTypedBase
+	N         int               `json:"n"`
+	Score     float64           `json:"score"`
+	Name      string            `json:"name"`
+	Enabled   bool              `json:"enabled"`
+	Tags      []string          `json:"tags"`
+	Labels    map[string]string `json:"labels"`
+	Owner     *testTypedBase    `json:"owner"`
+	Node      testTypedNode     `json:"node"`
+	Count     int64             `json:"count,string"`
+	CreatedAt time.Time         `json:"created_at"`
+	Raw       json.RawMessage   `json:"raw"`
+	Data      []byte            `json:"data"`
+	Any       interface{}       `json:"any"`
+	Untagged  uint8
+	Ignored   string `json:"-"`
+	private   string
+}
+
+func TestNewTypedLexer(t *testing.T) {
+	streamingJSONCase := map[string]string{
+		`{"n":`:                      `{"n":0}`,
+		`{"N":`:                      `{"N":0}`,
+		`{"tags":`:                   `{"tags":[]}`,
+		`{"tags":["a`:                `{"tags":["a"]}`,
+		`{"score"`:                   `{"score":0}`,
+		`{"name":`:                   `{"name":""}`,
+		`{"enabled":`:                `{"enabled":false}`,
+		`{"labels":`:                 `{"labels":{}}`,
+		`{"owner":`:                  `{"owner":{}}`,
+		`{"node":{"children":[{"val`: `{"node":{"children":[{"value":0}]}}`,
+		`{"count":`:                  `{"count":"0"}`,
+		`{"created_at":`:             `{"created_at":""}`,
+		`{"raw":`:                    `{"raw":null}`,
+		`{"data":`:                   `{"data":""}`,
+		`{"any":`:                    `{"any":null}`,
+		`{"Untagged":`:               `{"Untagged":0}`,
+		`{"i`:                        `{"id":""}`,
+		`{"unknown":`:                `{"unknown":null}`,
+	}
+	for testCase, expect := range streamingJSONCase {
+		lexer := NewTypedLexer[testTypedDocument]()
+		assert.Nil(t, lexer.AppendString(testCase))
+		completed := lexer.CompleteJSON()
+		assert.Equal(t, expect, completed, "unexpected completion for `%s`", testCase)
+	}
+}
+
+func TestNewTypedLexer_everyPrefixDecodable(t *testing.T) {
+	streamingJSONContent := `{"id": "x", "n": 1, "score": -1.5e3, "name": "a", "enabled": true, "tags": ["a", "b"], "labels": {"k": "v"}, "owner": null, "node": {"value": 1, "children": [{"value": 2, "children": null}]}, "count": "12", "created_at": "2024-01-02T03:04:05Z", "raw": [1, {"a": 2}], "data": "", "any": {"b": [true]}, "Untagged": 7}`
+	lexer := NewTypedLexer[testTypedDocument]()
+	for _, char := range streamingJSONContent {
+		assert.Nil(t, lexer.AppendString(string(char)))
+		completed := lexer.CompleteJSON()
+		var document testTypedDocument
+		// partial string of time.Time and `,string` field can not decode, like `""`
+		var properties map[string]json.RawMessage
+		assert.Nil(t, json.Unmarshal([]byte(completed), &properties), "invalid completion `%s`", completed)
+		delete(properties, "created_at")
+		delete(properties, "count")
+		propertiesInJSON, _ := json.Marshal(properties)
+		assert.Nil(t, json.Unmarshal(propertiesInJSON, &document), "completion `%s` can not decode into type", completed)
+	}
+}
+
+func TestNewTypedLexerWithValidation(t *testing.T) {
+	streamingJSONCase := map[string]string{
+		`{"n": 15, "owner": null, "tags": null, "node": {"children": [null]}}`: ``,
+		`{"n": "1"}`:                         "schema violation at `/n` (offset 6): expected integer, got string",
+		`{"n": 1.5}`:                         "schema violation at `/n` (offset 7): expected integer, got number with fraction or exponent",
+		`{"n": 1e3}`:                         "schema violation at `/n` (offset 7): expected integer, got number with fraction or exponent",
+		`{"tags": [1]}`:                      "schema violation at `/tags/0` (offset 10): expected string, got number",
+		`{"node": {"children": [{"x": 1}]}}`: "schema violation at `/node/children/0/x` (offset 25): properity `x` is not allowed",
+		`{"N": 1, "ID": "x", "Node": {"VALUE": 2}}`: ``,
+		`{"N": "1"}`:      "schema violation at `/N` (offset 6): expected integer, got string",
+		`{"Ignored": ""}`: "schema violation at `/Ig` (offset 3): properity `Ig` is not allowed",
+	}
+	for testCase, expect := range streamingJSONCase {
+		lexer := NewTypedLexerWithValidation[testTypedDocument]()
+		err := lexer.AppendString(testCase)
+		if expect == "" {
+			assert.Nil(t, err, "unexpected violation for `%s`", testCase)
+			continue
+		}
+		assert.EqualError(t, err, expect, "unexpected violation for `%s`", testCase)
+	}
+}
+
+func TestNewSchemaFromType_propertyNames(t *testing.T) {
+	schema := NewSchemaFromType(reflect.TypeOf(testTypedDocument{}))
+	assert.Equal(t, []string{"n", "score", "name", "enabled", "tags", "labels", "owner", "node", "count", "created_at", "raw", "data", "any", "Untagged", "id"}, schema.propertyNames)
+}
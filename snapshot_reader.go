@@ -0,0 +1,138 @@
+package streamingjsongo
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+const (
+	DEFAULT_SNAPSHOT_READ_SIZE  = 4096
+	MAX_CONSECUTIVE_EMPTY_READS = 100 // Next() returns io.ErrNoProgress after so many reads returned no data and no error in a row, like bufio
+)
+
+// options for snapshot reader
+type SnapshotReaderOptions struct {
+	ReadSize           int  // bytes read from underlying reader at most once, default DEFAULT_SNAPSHOT_READ_SIZE
+	SnapshotOnBoundary bool // only emit snapshot when a value (string, number, literal, object, array) finalized
+	Strict             bool // check JSON stream by JSON grammar with NewStrictLexer(), Next() returns *SyntaxError at the offending byte or the end of incomplete JSON stream
+}
+
+// snapshot reader wraps an io.Reader (like HTTP response body) and yields completed JSON snapshots
+type SnapshotReader struct {
+	reader          io.Reader
+	lexer           *Lexer
+	options         SnapshotReaderOptions
+	buffer          []byte
+	pendingBytes    []byte // incomplete UTF-8 sequence at the end of last chunk
+	finalizedValues int    // finalized values count at last emitted snapshot
+	haveUnemitted   bool   // have appended content not emitted as snapshot yet
+	err             error  // sticky error from underlying reader or lexer
+}
+
+// new snapshot reader, options can be nil for default options
+func NewSnapshotReader(r io.Reader, options *SnapshotReaderOptions) *SnapshotReader {
+	snapshotReader := &SnapshotReader{
+		reader: r,
+	}
+	if options != nil {
+		snapshotReader.options = *options
+	}
+	if snapshotReader.options.Strict {
+		snapshotReader.lexer = NewStrictLexer()
+	} else {
+		snapshotReader.lexer = NewLexer()
+	}
+	if snapshotReader.options.ReadSize <= 0 {
+		snapshotReader.options.ReadSize = DEFAULT_SNAPSHOT_READ_SIZE
+	}
+	snapshotReader.buffer = make([]byte, snapshotReader.options.ReadSize)
+	return snapshotReader
+}
+
+// get lexer used by snapshot reader
+func (snapshotReader *SnapshotReader) Lexer() *Lexer {
+	return snapshotReader.lexer
+}
+
+// read next chunk(s) from underlying reader, feed the lexer and return the latest completed JSON.
+// returns io.EOF after the last snapshot emitted, and io.ErrNoProgress if the underlying reader keeps returning no data and no error.
+func (snapshotReader *SnapshotReader) Next() (string, error) {
+	emptyReads := 0
+	for {
+		if snapshotReader.err != nil {
+			// flush held incomplete UTF-8 sequence, nothing more will arrive
+			if len(snapshotReader.pendingBytes) > 0 {
+				if errInAppendString := snapshotReader.appendChunk(nil); errInAppendString != nil {
+					snapshotReader.err = errInAppendString
+					return "", errInAppendString
+				}
+			}
+			// incomplete JSON stream is syntax error in strict mode
+			if snapshotReader.err == io.EOF {
+				if errInFinish := snapshotReader.lexer.Finish(); errInFinish != nil {
+					snapshotReader.err = errInFinish
+					return "", errInFinish
+				}
+			}
+			// emit the last snapshot if it was held by boundary option
+			if snapshotReader.haveUnemitted && snapshotReader.err == io.EOF {
+				return snapshotReader.emit(), nil
+			}
+			return "", snapshotReader.err
+		}
+		n, errInRead := snapshotReader.reader.Read(snapshotReader.buffer)
+		if errInRead != nil {
+			snapshotReader.err = errInRead
+		}
+		if n == 0 {
+			emptyReads++
+			if errInRead == nil && emptyReads >= MAX_CONSECUTIVE_EMPTY_READS {
+				snapshotReader.err = io.ErrNoProgress
+			}
+			continue
+		}
+		emptyReads = 0
+		if errInAppendString := snapshotReader.appendChunk(snapshotReader.buffer[:n]); errInAppendString != nil {
+			snapshotReader.err = errInAppendString
+			return "", errInAppendString
+		}
+		if !snapshotReader.options.SnapshotOnBoundary || snapshotReader.lexer.finalizedValues > snapshotReader.finalizedValues {
+			return snapshotReader.emit(), nil
+		}
+	}
+}
+
+// append chunk into lexer, the incomplete UTF-8 sequence at the end will be held until next chunk arrival
+func (snapshotReader *SnapshotReader) appendChunk(chunk []byte) error {
+	if len(snapshotReader.pendingBytes) > 0 {
+		chunk = append(snapshotReader.pendingBytes, chunk...)
+		snapshotReader.pendingBytes = nil
+	}
+	if snapshotReader.err == nil {
+		cut := incompleteUTF8SuffixStart(chunk)
+		snapshotReader.pendingBytes = append([]byte(nil), chunk[cut:]...)
+		chunk = chunk[:cut]
+	}
+	snapshotReader.haveUnemitted = true
+	return snapshotReader.lexer.AppendString(string(chunk))
+}
+
+// complete JSON and mark current state emitted
+func (snapshotReader *SnapshotReader) emit() string {
+	snapshotReader.finalizedValues = snapshotReader.lexer.finalizedValues
+	snapshotReader.haveUnemitted = false
+	return snapshotReader.lexer.CompleteJSON()
+}
+
+// find start of incomplete UTF-8 sequence at the end of given bytes, return len(b) if no such sequence
+func incompleteUTF8SuffixStart(b []byte) int {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if !utf8.FullRune(b[i:]) {
+				return i
+			}
+			break
+		}
+	}
+	return len(b)
+}
@@ -0,0 +1,92 @@
+package streamingjsongo
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotReader_readSize(t *testing.T) {
+	reader := NewSnapshotReader(strings.NewReader(`{"a":[true,"bc"]}`), &SnapshotReaderOptions{ReadSize: 6})
+	expects := []string{
+		`{"a":[]}`,          // `{"a":[`
+		`{"a":[true,""]}`,   // `true,"`
+		`{"a":[true,"bc"]}`, // `bc"]}`
+	}
+	for _, expect := range expects {
+		snapshot, err := reader.Next()
+		assert.Nil(t, err)
+		assert.Equal(t, expect, snapshot)
+	}
+	_, err := reader.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestSnapshotReader_onBoundary(t *testing.T) {
+	reader := NewSnapshotReader(iotest.OneByteReader(strings.NewReader(`{"a":"xyz","b":[1,nul`)), &SnapshotReaderOptions{SnapshotOnBoundary: true})
+	expects := []string{
+		`{"a":"xyz"}`,
+		`{"a":"xyz","b":[1]}`,
+		`{"a":"xyz","b":[1,null]}`, // last snapshot emitted at EOF
+	}
+	for _, expect := range expects {
+		snapshot, err := reader.Next()
+		assert.Nil(t, err)
+		assert.Equal(t, expect, snapshot)
+	}
+	_, err := reader.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestSnapshotReader_splitUTF8(t *testing.T) {
+	// `字` is 3 bytes in UTF-8, read size 7 splits it
+	reader := NewSnapshotReader(strings.NewReader(`{"a":"字符"}`), &SnapshotReaderOptions{ReadSize: 7})
+	var snapshots []string
+	for {
+		snapshot, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		snapshots = append(snapshots, snapshot)
+	}
+	assert.Equal(t, []string{`{"a":""}`, `{"a":"字符"}`}, snapshots)
+}
+
+func TestSnapshotReader_numberOnBoundary(t *testing.T) {
+	// the number is finalized by whitespace and closer, not only by comma
+	reader := NewSnapshotReader(iotest.OneByteReader(strings.NewReader(`[1 ,[2]`)), &SnapshotReaderOptions{SnapshotOnBoundary: true})
+	expects := []string{`[1]`, `[1 ,[2]]`}
+	for _, expect := range expects {
+		snapshot, err := reader.Next()
+		assert.Nil(t, err)
+		assert.Equal(t, expect, snapshot)
+	}
+	_, err := reader.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+// reader returns no data and no error forever
+type emptyReader struct{}
+
+func (emptyReader) Read(p []byte) (int, error) {
+	return 0, nil
+}
+
+func TestSnapshotReader_noProgress(t *testing.T) {
+	reader := NewSnapshotReader(emptyReader{}, nil)
+	_, err := reader.Next()
+	assert.Equal(t, io.ErrNoProgress, err)
+}
+
+func TestSnapshotReader_strict(t *testing.T) {
+	reader := NewSnapshotReader(strings.NewReader(`{"a":[1`), &SnapshotReaderOptions{Strict: true})
+	snapshot, err := reader.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":[1]}`, snapshot)
+	_, err = reader.Next()
+	assert.Equal(t, &SyntaxError{Offset: 7, Message: "unexpected end of JSON stream"}, err)
+}
@@ -0,0 +1,207 @@
+package streamingjsongo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// kinds of JSON token
+const (
+	TOKEN_KIND_BEGIN_OBJECT = iota
+	TOKEN_KIND_END_OBJECT
+	TOKEN_KIND_BEGIN_ARRAY
+	TOKEN_KIND_END_ARRAY
+	TOKEN_KIND_KEY
+	TOKEN_KIND_STRING
+	TOKEN_KIND_NUMBER
+	TOKEN_KIND_BOOL
+	TOKEN_KIND_NULL
+)
+
+// names of token kinds
+var tokenKindNames = []string{"BeginObject", "EndObject", "BeginArray", "EndArray", "Key", "String", "Number", "Bool", "Null"}
+
+// typed JSON token of JSON stream
+type JSONToken struct {
+	Kind    int    // TOKEN_KIND_*
+	Raw     string // raw JSON of the token appended so far, like `"hel` if it is partial
+	Value   string // decoded content of key and string, the partial content if it is partial, or raw JSON of the others
+	Offset  int    // offset of the token start in the appended JSON stream
+	End     int    // offset of the token end (exclusive) in the appended JSON stream
+	Partial bool   // the token is still streaming, it is returned again when it grows or completes
+}
+
+// get name of token kind, like `BeginObject`
+func TokenKindName(kind int) string {
+	if kind < 0 || kind >= len(tokenKindNames) {
+		return "UNKNOWN"
+	}
+	return tokenKindNames[kind]
+}
+
+// tokenizer of JSON stream, yields typed JSON tokens across the JSON segments appended to lexer.
+// Next() returns the next token, the partial token if the JSON stream stops in a token and it grew since last call,
+// and ErrNeedMoreData if no token can be returned until more JSON segment appended.
+type Tokenizer struct {
+	lexer        *Lexer
+	scanned      int    // offset in JSONContent of the next token
+	containers   []bool // the open containers, true for object
+	expectingKey bool   // the next string in object is properity key
+	partialLen   int    // length of the partial token returned last time, 0 if none
+	stringEnd    int    // offset in JSONContent the string in progress scanned to
+	escaped      bool   // the string in progress scanned to an escape character
+}
+
+// get tokenizer yielding tokens of JSON stream from the start.
+// the tokens are scanned from JSON content, so the bytes dropped by lexer (like the dangling `-` of `[-]`) are not yielded.
+func (lexer *Lexer) Tokenizer() *Tokenizer {
+	return &Tokenizer{lexer: lexer}
+}
+
+// get the next token of JSON stream
+func (tokenizer *Tokenizer) Next() (JSONToken, error) {
+	if tokenizer.lexer.flush.writer != nil {
+		return JSONToken{}, ErrContentFlushed
+	}
+	content := tokenizer.lexer.JSONContent.String()
+	for tokenizer.scanned < len(content) {
+		c := content[tokenizer.scanned]
+		switch {
+		case isIgnoreToken(c), c == TOKEN_COLON_SYMBOL:
+			tokenizer.scanned++
+		case c == TOKEN_COMMA_SYMBOL:
+			tokenizer.expectingKey = tokenizer.inObject()
+			tokenizer.scanned++
+		case c == TOKEN_LEFT_BRACE_SYMBOL, c == TOKEN_LEFT_BRACKET_SYMBOL:
+			tokenizer.containers = append(tokenizer.containers, c == TOKEN_LEFT_BRACE_SYMBOL)
+			tokenizer.expectingKey = c == TOKEN_LEFT_BRACE_SYMBOL
+			kind := TOKEN_KIND_BEGIN_ARRAY
+			if c == TOKEN_LEFT_BRACE_SYMBOL {
+				kind = TOKEN_KIND_BEGIN_OBJECT
+			}
+			return tokenizer.emit(content, kind, tokenizer.scanned+1, false), nil
+		case c == TOKEN_RIGHT_BRACE_SYMBOL, c == TOKEN_RIGHT_BRACKET_SYMBOL:
+			if containersLen := len(tokenizer.containers); containersLen > 0 {
+				tokenizer.containers = tokenizer.containers[:containersLen-1]
+			}
+			tokenizer.expectingKey = false
+			kind := TOKEN_KIND_END_ARRAY
+			if c == TOKEN_RIGHT_BRACE_SYMBOL {
+				kind = TOKEN_KIND_END_OBJECT
+			}
+			return tokenizer.emit(content, kind, tokenizer.scanned+1, false), nil
+		case c == TOKEN_QUOTE_SYMBOL:
+			return tokenizer.nextString(content)
+		case c == TOKEN_NEGATIVE_SYMBOL || isDigit(c):
+			return tokenizer.nextNumber(content)
+		case c == TOKEN_ALPHABET_LOWERCASE_T_SYMBOL || c == TOKEN_ALPHABET_LOWERCASE_F_SYMBOL:
+			return tokenizer.nextLiteral(content, TOKEN_KIND_BOOL)
+		case c == TOKEN_ALPHABET_LOWERCASE_N_SYMBOL:
+			return tokenizer.nextLiteral(content, TOKEN_KIND_NULL)
+		default:
+			offset := tokenizer.lexer.sourceMap.streamOffset(tokenizer.scanned)
+			return JSONToken{}, &SyntaxError{Offset: offset, Message: fmt.Sprintf("invalid character %q looking for beginning of token", []byte{c})}
+		}
+	}
+	return JSONToken{}, ErrNeedMoreData
+}
+
+// check if the innermost open container is object
+func (tokenizer *Tokenizer) inObject() bool {
+	containersLen := len(tokenizer.containers)
+	return containersLen > 0 && tokenizer.containers[containersLen-1]
+}
+
+// get the string or key token starting at scanned offset
+func (tokenizer *Tokenizer) nextString(content string) (JSONToken, error) {
+	if tokenizer.stringEnd <= tokenizer.scanned {
+		tokenizer.stringEnd = tokenizer.scanned + 1
+		tokenizer.escaped = false
+	}
+	kind := TOKEN_KIND_STRING
+	if tokenizer.expectingKey && tokenizer.inObject() {
+		kind = TOKEN_KIND_KEY
+	}
+	for ; tokenizer.stringEnd < len(content); tokenizer.stringEnd++ {
+		switch c := content[tokenizer.stringEnd]; {
+		case tokenizer.escaped:
+			tokenizer.escaped = false
+		case c == TOKEN_ESCAPE_CHARACTER_SYMBOL:
+			tokenizer.escaped = true
+		case c == TOKEN_QUOTE_SYMBOL:
+			tokenizer.expectingKey = false
+			return tokenizer.emit(content, kind, tokenizer.stringEnd+1, false), nil
+		}
+	}
+	return tokenizer.emitPartial(content, kind, len(content))
+}
+
+// get the number token starting at scanned offset
+func (tokenizer *Tokenizer) nextNumber(content string) (JSONToken, error) {
+	end := tokenizer.scanned + 1
+	for end < len(content) && strings.IndexByte("0123456789.eE+-", content[end]) >= 0 {
+		end++
+	}
+	if end < len(content) {
+		return tokenizer.emit(content, TOKEN_KIND_NUMBER, end, false), nil
+	}
+	// the number at content end is complete if followed by whitespace or comma in padding content
+	lexer := tokenizer.lexer
+	if lexer.havePaddingContent() {
+		if c := lexer.paddingContent[0]; isIgnoreToken(c) || c == TOKEN_COMMA_SYMBOL {
+			return tokenizer.emit(content, TOKEN_KIND_NUMBER, end, false), nil
+		}
+	}
+	return tokenizer.emitPartial(content, TOKEN_KIND_NUMBER, end)
+}
+
+// get the literal token (true, false or null) starting at scanned offset
+func (tokenizer *Tokenizer) nextLiteral(content string, kind int) (JSONToken, error) {
+	literal := "null"
+	switch content[tokenizer.scanned] {
+	case TOKEN_ALPHABET_LOWERCASE_T_SYMBOL:
+		literal = "true"
+	case TOKEN_ALPHABET_LOWERCASE_F_SYMBOL:
+		literal = "false"
+	}
+	end := tokenizer.scanned + len(literal)
+	if end <= len(content) {
+		return tokenizer.emit(content, kind, end, false), nil
+	}
+	return tokenizer.emitPartial(content, kind, len(content))
+}
+
+// emit the partial token ending at given offset if it grew since last time
+func (tokenizer *Tokenizer) emitPartial(content string, kind int, end int) (JSONToken, error) {
+	if end-tokenizer.scanned <= tokenizer.partialLen {
+		return JSONToken{}, ErrNeedMoreData
+	}
+	return tokenizer.emit(content, kind, end, true), nil
+}
+
+// emit the token from scanned offset to given end offset, and move to the next token if it is complete
+func (tokenizer *Tokenizer) emit(content string, kind int, end int, partial bool) JSONToken {
+	sourceMap := &tokenizer.lexer.sourceMap
+	token := JSONToken{
+		Kind:    kind,
+		Raw:     content[tokenizer.scanned:end],
+		Offset:  sourceMap.streamOffset(tokenizer.scanned),
+		End:     sourceMap.streamOffset(end-1) + 1,
+		Partial: partial,
+	}
+	token.Value = token.Raw
+	if kind == TOKEN_KIND_KEY || kind == TOKEN_KIND_STRING {
+		raw := token.Raw[1:]
+		if !partial {
+			raw = raw[:len(raw)-1]
+		}
+		token.Value = decodeJSONStringContent(raw)
+	}
+	if partial {
+		tokenizer.partialLen = end - tokenizer.scanned
+		return token
+	}
+	tokenizer.partialLen = 0
+	tokenizer.scanned = end
+	return token
+}
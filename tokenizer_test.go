@@ -0,0 +1,126 @@
+package streamingjsongo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// read all tokens arrived so far from tokenizer
+func readTokens(t *testing.T, tokenizer *Tokenizer) []JSONToken {
+	var tokens []JSONToken
+	for {
+		token, err := tokenizer.Next()
+		if err != nil {
+			assert.Equal(t, ErrNeedMoreData, err)
+			return tokens
+		}
+		tokens = append(tokens, token)
+	}
+}
+
+func TestTokenizer(t *testing.T) {
+	document := ` {"a\nb": [1, -2.5e+3, "x\"y你"], "c": {"d": true, "e": null}, "f": [false, {}]}`
+	expected := []JSONToken{
+		{TOKEN_KIND_BEGIN_OBJECT, `{`, `{`, 1, 2, false},
+		{TOKEN_KIND_KEY, `"a\nb"`, "a\nb", 2, 8, false},
+		{TOKEN_KIND_BEGIN_ARRAY, `[`, `[`, 10, 11, false},
+		{TOKEN_KIND_NUMBER, `1`, `1`, 11, 12, false},
+		{TOKEN_KIND_NUMBER, `-2.5e+3`, `-2.5e+3`, 14, 21, false},
+		{TOKEN_KIND_STRING, `"x\"y你"`, "x\"y你", 23, 32, false},
+		{TOKEN_KIND_END_ARRAY, `]`, `]`, 32, 33, false},
+		{TOKEN_KIND_KEY, `"c"`, `c`, 35, 38, false},
+		{TOKEN_KIND_BEGIN_OBJECT, `{`, `{`, 40, 41, false},
+		{TOKEN_KIND_KEY, `"d"`, `d`, 41, 44, false},
+		{TOKEN_KIND_BOOL, `true`, `true`, 46, 50, false},
+		{TOKEN_KIND_KEY, `"e"`, `e`, 52, 55, false},
+		{TOKEN_KIND_NULL, `null`, `null`, 57, 61, false},
+		{TOKEN_KIND_END_OBJECT, `}`, `}`, 61, 62, false},
+		{TOKEN_KIND_KEY, `"f"`, `f`, 64, 67, false},
+		{TOKEN_KIND_BEGIN_ARRAY, `[`, `[`, 69, 70, false},
+		{TOKEN_KIND_BOOL, `false`, `false`, 70, 75, false},
+		{TOKEN_KIND_BEGIN_OBJECT, `{`, `{`, 77, 78, false},
+		{TOKEN_KIND_END_OBJECT, `}`, `}`, 78, 79, false},
+		{TOKEN_KIND_END_ARRAY, `]`, `]`, 79, 80, false},
+		{TOKEN_KIND_END_OBJECT, `}`, `}`, 80, 81, false},
+	}
+	for _, chunkSize := range []int{1, 5, len(document)} {
+		lexer := NewLexer()
+		tokenizer := lexer.Tokenizer()
+		var tokens []JSONToken
+		for _, chunk := range splitIntoChunks(document, chunkSize) {
+			assert.Nil(t, lexer.AppendString(chunk))
+			for _, token := range readTokens(t, tokenizer) {
+				assert.Equal(t, document[token.Offset:token.End], token.Raw)
+				if !token.Partial {
+					tokens = append(tokens, token)
+				}
+			}
+		}
+		assert.Equal(t, expected, tokens, "chunk size %d", chunkSize)
+	}
+}
+
+func TestTokenizer_partial(t *testing.T) {
+	lexer := NewLexer()
+	tokenizer := lexer.Tokenizer()
+	lexer.AppendString(`{"message": "hel`)
+	assert.Equal(t, []JSONToken{
+		{TOKEN_KIND_BEGIN_OBJECT, `{`, `{`, 0, 1, false},
+		{TOKEN_KIND_KEY, `"message"`, `message`, 1, 10, false},
+		{TOKEN_KIND_STRING, `"hel`, `hel`, 12, 16, true},
+	}, readTokens(t, tokenizer))
+
+	// the partial token is returned again only when it grows
+	lexer.AppendString(`\`)
+	assert.Nil(t, readTokens(t, tokenizer))
+	lexer.AppendString(`n`)
+	assert.Equal(t, []JSONToken{{TOKEN_KIND_STRING, `"hel\n`, "hel\n", 12, 18, true}}, readTokens(t, tokenizer))
+	lexer.AppendString(`lo", "n": [12`)
+	assert.Equal(t, []JSONToken{
+		{TOKEN_KIND_STRING, `"hel\nlo"`, "hel\nlo", 12, 21, false},
+		{TOKEN_KIND_KEY, `"n"`, `n`, 23, 26, false},
+		{TOKEN_KIND_BEGIN_ARRAY, `[`, `[`, 28, 29, false},
+		{TOKEN_KIND_NUMBER, `12`, `12`, 29, 31, true},
+	}, readTokens(t, tokenizer))
+
+	// the number is complete when followed by whitespace or comma
+	lexer.AppendString(` `)
+	assert.Equal(t, []JSONToken{{TOKEN_KIND_NUMBER, `12`, `12`, 29, 31, false}}, readTokens(t, tokenizer))
+	lexer.AppendString(`, fa`)
+	assert.Equal(t, []JSONToken{{TOKEN_KIND_BOOL, `fa`, `fa`, 34, 36, true}}, readTokens(t, tokenizer))
+	lexer.AppendString(`lse]}`)
+	assert.Equal(t, []JSONToken{
+		{TOKEN_KIND_BOOL, `false`, `false`, 34, 39, false},
+		{TOKEN_KIND_END_ARRAY, `]`, `]`, 39, 40, false},
+		{TOKEN_KIND_END_OBJECT, `}`, `}`, 40, 41, false},
+	}, readTokens(t, tokenizer))
+}
+
+func TestTokenizer_droppedBytes(t *testing.T) {
+	lexer := NewLexer()
+	lexer.AppendString(`{"a": [-], "b": 1}`)
+	tokens := readTokens(t, lexer.Tokenizer())
+	assert.Equal(t, JSONToken{TOKEN_KIND_END_ARRAY, `]`, `]`, 8, 9, false}, tokens[3])
+	assert.Equal(t, JSONToken{TOKEN_KIND_NUMBER, `1`, `1`, 16, 17, false}, tokens[5])
+}
+
+func TestTokenizer_error(t *testing.T) {
+	lexer := NewLexer()
+	lexer.AppendString(`[1x`)
+	tokenizer := lexer.Tokenizer()
+	token, err := tokenizer.Next()
+	assert.Equal(t, TOKEN_KIND_BEGIN_ARRAY, token.Kind)
+	assert.Nil(t, err)
+	token, err = tokenizer.Next()
+	assert.Equal(t, JSONToken{TOKEN_KIND_NUMBER, `1`, `1`, 1, 2, false}, token)
+	assert.Nil(t, err)
+	_, err = tokenizer.Next()
+	assert.Equal(t, &SyntaxError{Offset: 2, Message: `invalid character "x" looking for beginning of token`}, err)
+}
+
+func TestTokenKindName(t *testing.T) {
+	assert.Equal(t, "BeginObject", TokenKindName(TOKEN_KIND_BEGIN_OBJECT))
+	assert.Equal(t, "Null", TokenKindName(TOKEN_KIND_NULL))
+	assert.Equal(t, "UNKNOWN", TokenKindName(-1))
+}